@@ -2,11 +2,17 @@
 package mangodex
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -25,21 +31,39 @@ type DexResponse struct {
 
 // DexClient: The MangaDex client.
 type DexClient struct {
-	client *http.Client
-	header http.Header
+	client   *http.Client
+	header   http.Header
+	headerMu sync.RWMutex
+	limiter  *rateLimiter
+	retry    RetryPolicy
+	session  *session
 
-	common       service
-	refreshToken string // Unused
+	tagResolver *TagResolver
+
+	baseAPI         *url.URL
+	baseAuth        string
+	mdHomeReportURL string
+	baseUploads     string
+
+	clientID     string
+	clientSecret string
+
+	common service
 
 	// Services for MangaDex API.
-	Auth            *AuthService // Deprecated
+	Auth            *AuthService
 	Manga           *MangaService
 	Volume          *VolumeService
 	Chapter         *ChapterService
 	Cover           *CoverService
-	User            *UserService // Deprecated
+	User            *UserService
 	AtHome          *AtHomeService
 	ScanlationGroup *ScanlationGroupService
+	Author          *AuthorService
+	Tag             *TagService
+	CustomList      *CustomListService
+	Follow          *FollowService
+	Rating          *RatingService
 }
 
 // service: Wrapper for DexClient.
@@ -52,15 +76,42 @@ func NewDexClient(options Options) *DexClient {
 	if err := options.validate(); err != nil {
 		panic(fmt.Errorf("Invalid MangoDex client options: %s", err.Error()))
 	}
+	options = options.withDefaults()
+
+	baseAPI, _ := url.Parse(options.BaseAPI)
 
 	client := http.Client{}
+	if options.HTTPClient != nil {
+		client = *options.HTTPClient
+	}
+	if len(options.Middleware) != 0 {
+		transport := client.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		client.Transport = chain(transport, options.Middleware)
+	}
 	header := http.Header{}
 	header.Set("Content-Type", "application/json")
 	header.Set("User-Agent", options.UserAgent)
 
 	dex := &DexClient{
-		client: &client,
-		header: header,
+		client:  &client,
+		header:  header,
+		limiter: newRateLimiter(options.RateLimit, options.RouteRateLimits),
+		retry:   options.Retry,
+		session: &session{store: options.TokenStore},
+
+		baseAPI:         baseAPI,
+		baseAuth:        strings.TrimSuffix(options.BaseAuth, "/"),
+		mdHomeReportURL: options.MDHomeReportURL,
+		baseUploads:     strings.TrimSuffix(options.BaseUploads, "/"),
+
+		clientID:     options.ClientID,
+		clientSecret: options.ClientSecret,
+	}
+	if dex.session.store == nil {
+		dex.session.store = &MemoryTokenStore{}
 	}
 	dex.common.client = dex
 
@@ -73,48 +124,133 @@ func NewDexClient(options Options) *DexClient {
 	dex.User = (*UserService)(&dex.common)
 	dex.AtHome = (*AtHomeService)(&dex.common)
 	dex.ScanlationGroup = (*ScanlationGroupService)(&dex.common)
+	dex.Author = (*AuthorService)(&dex.common)
+	dex.Tag = (*TagService)(&dex.common)
+	dex.CustomList = (*CustomListService)(&dex.common)
+	dex.Follow = (*FollowService)(&dex.common)
+	dex.Rating = (*RatingService)(&dex.common)
+	dex.tagResolver = &TagResolver{service: dex.Tag}
 
 	return dex
 }
 
 // Request: Sends a request to the MangaDex API.
+//
+// Failed idempotent requests are retried according to the client's RetryPolicy.
 func (c *DexClient) Request(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	retry := c.retry.allows(method)
+
+	// Keep the body around so it can be sent again on each attempt.
+	var payload []byte
+	if retry && body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+
+		resp, err := c.do(ctx, method, url, body)
+		if err == nil && resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+		if err == nil {
+			err = statusError(method, url, resp)
+		}
+
+		if !retry || attempt >= c.retry.MaxAttempts || !c.retry.retryable(method, resp, err) || ctx.Err() != nil {
+			if attempt > 1 {
+				err = fmt.Errorf("Failed after %d attempts: %w", attempt, err)
+			}
+			return nil, err
+		}
+
+		// Don't wait if the retry couldn't happen before the deadline anyway.
+		delay := c.retry.delay(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return nil, fmt.Errorf("Failed after %d attempts: %w", attempt, err)
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return nil, fmt.Errorf("Failed after %d attempts: %w", attempt, errors.Join(err, ctx.Err()))
+		}
+	}
+}
+
+// requestOnce: Send a request like Request, but without retrying it.
+func (c *DexClient) requestOnce(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
+	resp, err := c.do(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
-	req.Header = c.header
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(method, url, resp)
+	}
+	return resp, nil
+}
 
-	resp, err := c.client.Do(req)
+// do: Send a single request, without checking the response status.
+func (c *DexClient) do(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
+	// Each request gets its own copy of the common headers, so nothing is shared between requests.
+	req.Header = c.Header()
 
-	switch resp.StatusCode {
-	case 200:
-		return resp, nil
-	case 403:
-		return nil, fmt.Errorf("403 Forbidden: Probably temporarily IP banned")
-	case 429:
-		retryAfter := resp.Header.Get("Retry-After")
-		return nil, fmt.Errorf("429 Too Many Requests: Retry-After: %s", retryAfter)
-	case 503:
-		// Special case for maintenance responses.
-		return nil, fmt.Errorf("503 Service Unavailable: MangaDex is temporarily down for maintenance")
-	default:
-		defer resp.Body.Close()
-		var er ErrorResponse
-		var errMsg string
-		err = json.NewDecoder(resp.Body).Decode(&er)
-		// Sometimes the error page is just plain HTML, so it can't be decoded into ErrorResponse
+	if c.isAPIRequest(req.URL) {
+		token, err := c.Auth.accessToken(ctx)
 		if err != nil {
-			errMsg = fmt.Sprintf("Failed to decode into ErrorResponse (HTML response?): %s", err.Error())
-		} else {
-			errMsg = er.GetErrors()
+			return nil, err
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
 		}
 
-		return nil, fmt.Errorf("Non-200 status code -> (%d): %s", resp.StatusCode, errMsg)
+		if err := c.limiter.Wait(ctx, strings.TrimPrefix(req.URL.Path, c.baseAPI.Path)); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range headerFromContext(ctx) {
+		req.Header[k] = v
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, &transportError{err: err}
+	}
+	return resp, nil
+}
+
+// statusError: Build the error for a non-200 response to the request, closing its body.
+func statusError(method, url string, resp *http.Response) error {
+	defer resp.Body.Close()
+	return newAPIError(method, url, resp)
+}
+
+// apiURL: Build the URL for an API path, relative to the client's base API URL.
+func (c *DexClient) apiURL(path string) *url.URL {
+	u := *c.baseAPI
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	return &u
+}
+
+// isAPIRequest: Whether the URL points to the MangaDex API, as opposed to MD@Home nodes and such.
+func (c *DexClient) isAPIRequest(u *url.URL) bool {
+	if u.Host != c.baseAPI.Host {
+		return false
 	}
+	// Match whole path segments, so that siblings of the base path (/api and /apix) don't match.
+	base := strings.TrimSuffix(c.baseAPI.Path, "/")
+	return u.Path == base || strings.HasPrefix(u.Path, base+"/")
 }
 
 // RequestAndDecode: Convenience wrapper to also decode response to given interface.
@@ -127,3 +263,20 @@ func (c *DexClient) RequestAndDecode(ctx context.Context, method, url string, bo
 
 	return json.NewDecoder(resp.Body).Decode(&res)
 }
+
+// unmarshalObject: Unmarshal a JSON object into the map, accepting the empty array
+// MangaDex sends instead of an empty object.
+func unmarshalObject[V any](data []byte, m *map[string]V) error {
+	var values map[string]V
+	if err := json.Unmarshal(data, &values); err == nil {
+		*m = values
+		return nil
+	}
+
+	var empty []any
+	if err := json.Unmarshal(data, &empty); err != nil || len(empty) != 0 {
+		return fmt.Errorf("unexpected object: %s", string(data))
+	}
+	*m = map[string]V{}
+	return nil
+}
@@ -1,15 +1,46 @@
 package mangodex
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // TODO: refactor all the tests
 
 var client = NewDexClient(DefaultOptions())
 
+// newTestClient: Create a client pointed at a local stand-in server for both the API and auth.
+func newTestClient(t *testing.T, handler http.Handler, configure ...func(*Options)) *DexClient {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	options := DefaultOptions()
+	options.BaseAPI = ts.URL + "/api"
+	options.BaseAuth = ts.URL + "/auth"
+	options.ClientID = "client-id"
+	options.ClientSecret = "client-secret"
+	options.Retry.MinBackoff = time.Millisecond
+	for _, f := range configure {
+		f(&options)
+	}
+	return NewDexClient(options)
+}
+
 //
 // manga.go
 //
@@ -59,6 +90,101 @@ func TestMangaGroupList(t *testing.T) {
 	}
 }
 
+func TestMangaGetStandIn(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/manga/some-id" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		w.Write([]byte(`{"result":"ok","data":{"id":"some-id","type":"manga","attributes":{"title":{"en":"Some Manga"}}}}`))
+	}))
+
+	manga, err := c.Manga.Get("some-id", url.Values{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title := manga.GetTitle("en", false); title != "Some Manga" {
+		t.Errorf("Unexpected title %q", title)
+	}
+}
+
+func TestMangaListParams(t *testing.T) {
+	params := MangaListParams{
+		Limit:            10,
+		Title:            "tengoku daimakyou",
+		IncludedTags:     []string{"a", "b"},
+		IncludedTagsMode: TagsModeAnd,
+		ContentRating:    []ContentRating{ContentRatingSafe, ContentRatingSuggestive},
+		UpdatedAtSince:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Order:            MangaOrder{FollowedCount: OrderDescending},
+		Includes:         []RelationshipType{RelationshipTypeCoverArt},
+	}
+	v, err := params.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "contentRating%5B%5D=safe&contentRating%5B%5D=suggestive&includedTagsMode=AND&includedTags%5B%5D=a" +
+		"&includedTags%5B%5D=b&includes%5B%5D=cover_art&limit=10&order%5BfollowedCount%5D=desc" +
+		"&title=tengoku+daimakyou&updatedAtSince=2024-01-02T03%3A04%3A05"
+	if encoded := v.Encode(); encoded != expected {
+		t.Errorf("Unexpected encoded params:\n%s\nwanted:\n%s", encoded, expected)
+	}
+
+	invalid := []MangaListParams{
+		{Limit: 101},
+		{Offset: 9990, Limit: 20},
+		{IncludedTagsMode: "XOR"},
+		{IncludedTags: []string{"a"}, ExcludedTags: []string{"a"}},
+		{ContentRating: []ContentRating{"nsfw"}},
+		{Order: MangaOrder{Relevance: OrderDescending}},
+	}
+	for _, p := range invalid {
+		if _, err := p.Values(); err == nil {
+			t.Errorf("Expected validation error for %+v", p)
+		}
+	}
+}
+
+func TestMangaRandom(t *testing.T) {
+	var query string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/manga/random" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"result":"ok","data":{"id":"random-id","type":"manga"}}`))
+	}))
+
+	params, err := MangaRandomParams{
+		ContentRating:    []ContentRating{ContentRatingSafe},
+		ExcludedTags:     []string{"a"},
+		ExcludedTagsMode: TagsModeOr,
+		Includes:         []RelationshipType{RelationshipTypeAuthor, RelationshipTypeCoverArt},
+	}.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	manga, err := c.Manga.Random(params)
+	if err != nil || manga.ID != "random-id" {
+		t.Fatalf("Unexpected random manga %+v: %v", manga, err)
+	}
+	expected := "contentRating%5B%5D=safe&excludedTagsMode=OR&excludedTags%5B%5D=a" +
+		"&includes%5B%5D=author&includes%5B%5D=cover_art"
+	if query != expected {
+		t.Errorf("Unexpected encoded params:\n%s\nwanted:\n%s", query, expected)
+	}
+
+	invalid := []MangaRandomParams{
+		{IncludedTagsMode: "XOR"},
+		{IncludedTags: []string{"a"}, ExcludedTags: []string{"a"}},
+		{Includes: []RelationshipType{RelationshipTypeChapter}},
+	}
+	for _, p := range invalid {
+		if _, err := p.Values(); err == nil {
+			t.Errorf("Expected validation error for %+v", p)
+		}
+	}
+}
+
 // scanlation_group.go
 
 func TestGroupGet(t *testing.T) {
@@ -90,6 +216,95 @@ func TestChapterGet(t *testing.T) {
 	}
 }
 
+func TestChapterFeedParams(t *testing.T) {
+	yes := true
+	params := ChapterFeedParams{
+		Limit:                500,
+		TranslatedLanguage:   []string{"en"},
+		ExcludedGroups:       []string{"g"},
+		IncludeFutureUpdates: &yes,
+		PublishAtSince:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("UTC-1", -3600)),
+		Order:                ChapterOrder{Volume: OrderAscending, Chapter: OrderAscending},
+	}
+	v, err := params.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := url.Values{
+		"limit":                {"500"},
+		"translatedLanguage[]": {"en"},
+		"excludedGroups[]":     {"g"},
+		"includeFutureUpdates": {"1"},
+		"publishAtSince":       {"2024-01-02T04:04:05"},
+		"order[volume]":        {"asc"},
+		"order[chapter]":       {"asc"},
+	}
+	if v.Encode() != expected.Encode() {
+		t.Errorf("Unexpected encoded params %q, wanted %q", v.Encode(), expected.Encode())
+	}
+
+	invalid := ChapterFeedParams{Groups: []string{"g"}, ExcludedGroups: []string{"g"}}
+	if _, err := invalid.Values(); err == nil {
+		t.Error("Expected validation error for included and excluded group")
+	}
+}
+
+func TestGetReadMangaChaptersBatch(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/manga/read" || r.URL.Query().Get("grouped") != "true" {
+			t.Errorf("Unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		// MangaDex sends an empty array instead of an empty object.
+		if slices.Contains(r.URL.Query()["ids[]"], "unread") {
+			w.Write([]byte(`{"result":"ok","data":[]}`))
+			return
+		}
+		w.Write([]byte(`{"result":"ok","data":{"a":["c1","c2"]}}`))
+	}))
+
+	read, err := c.Chapter.GetReadMangaChaptersBatch("a", "b")
+	if err != nil || !slices.Equal(read["a"], []string{"c1", "c2"}) || len(read) != 1 {
+		t.Errorf("Unexpected read markers %v: %v", read, err)
+	}
+	read, err = c.Chapter.GetReadMangaChaptersBatch("unread")
+	if err != nil || len(read) != 0 {
+		t.Errorf("Unexpected read markers %v: %v", read, err)
+	}
+}
+
+func TestSyncReadMangaChapters(t *testing.T) {
+	var pushed map[string][]string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/manga/some-id/read" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		if r.Method == http.MethodPost {
+			if r.URL.Query().Get("updateHistory") != "true" {
+				t.Errorf("Missing updateHistory in %q", r.URL.RawQuery)
+			}
+			json.NewDecoder(r.Body).Decode(&pushed)
+		}
+		w.Write([]byte(`{"result":"ok","data":["a","b"]}`))
+	}))
+
+	delta, err := c.Chapter.SyncReadMangaChapters("some-id", []string{"b", "c"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(delta.Read, []string{"c"}) || !slices.Equal(delta.Unread, []string{"a"}) {
+		t.Errorf("Unexpected delta %+v", delta)
+	}
+	if !slices.Equal(pushed["chapterIdsRead"], delta.Read) || !slices.Equal(pushed["chapterIdsUnread"], delta.Unread) {
+		t.Errorf("Unexpected pushed markers %v", pushed)
+	}
+
+	// Nothing is pushed when already in sync.
+	pushed = nil
+	if _, err = c.Chapter.SyncReadMangaChapters("some-id", []string{"a", "b"}, true); err != nil || pushed != nil {
+		t.Errorf("Unexpected push %v: %v", pushed, err)
+	}
+}
+
 //
 // cover.go
 //
@@ -145,6 +360,215 @@ func TestVolumeEmpty(t *testing.T) {
 	}
 }
 
+//
+// author.go
+//
+
+func TestAuthorList(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/author" || r.URL.Query().Get("name") != "oda" || r.URL.Query().Get("order[name]") != "asc" {
+			t.Errorf("Unexpected request %q", r.URL.String())
+		}
+		w.Write([]byte(`{"result":"ok","data":[{"id":"a","type":"author","attributes":{"name":"Oda Eiichiro","twitter":"https://twitter.com/x"}}],"limit":10,"offset":0,"total":1}`))
+	}))
+
+	params, err := AuthorListParams{Name: "oda", Order: AuthorOrder{Name: OrderAscending}}.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := c.Author.ListPage(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 1 || res.Data[0].GetName() != "Oda Eiichiro" || *res.Data[0].Attributes.Twitter != "https://twitter.com/x" {
+		t.Errorf("Unexpected author list %+v", res)
+	}
+}
+
+//
+// relation.go
+//
+
+func TestMangaFranchise(t *testing.T) {
+	const (
+		a = "00000000-0000-0000-0000-00000000000a"
+		b = "00000000-0000-0000-0000-00000000000b"
+		c = "00000000-0000-0000-0000-00000000000c"
+		d = "00000000-0000-0000-0000-00000000000d"
+	)
+	relations := map[string][][2]string{
+		a: {{b, "sequel"}, {d, "doujinshi"}},
+		b: {{a, "prequel"}, {c, "side_story"}},
+		c: {{b, "main_story"}, {d, "sequel"}},
+	}
+	var requested []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.Split(r.URL.Path, "/")[3]
+		requested = append(requested, id)
+		data := []any{}
+		for _, rel := range relations[id] {
+			data = append(data, map[string]any{
+				"id": "relation", "type": "manga_relation",
+				"attributes":    map[string]any{"relation": rel[1]},
+				"relationships": []any{map[string]any{"id": rel[0], "type": "manga"}},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"result": "ok", "data": data, "total": len(data)})
+	}), func(o *Options) {
+		o.RateLimit = RateLimit{}
+	})
+
+	graph, err := client.Manga.Franchise(a, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The doujinshi isn't followed, and c's relations are past the depth limit.
+	if nodes := graph.Nodes(); !slices.Equal(nodes, []string{a, b, c}) {
+		t.Errorf("Unexpected franchise nodes %v", nodes)
+	}
+	if !slices.Equal(requested, []string{a, b}) {
+		t.Errorf("Unexpected relation requests %v", requested)
+	}
+	if edges := graph.Edges[b]; len(edges) != 2 || edges[1] != (FranchiseEdge{From: b, To: c, Relation: MangaRelationSideStory}) {
+		t.Errorf("Unexpected edges %+v", edges)
+	}
+}
+
+//
+// tag.go
+//
+
+func TestTagResolver(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{"result":"ok","data":[
+			{"id":"ace04997-f6bd-436e-b261-779182193d3d","type":"tag","attributes":{"name":{"en":"Isekai"},"group":"theme"}},
+			{"id":"423e2eae-a7a2-4a8b-ac03-a8351462d71d","type":"tag","attributes":{"name":{"en":"Romance"},"group":"genre"}}
+		],"limit":100,"offset":0,"total":2}`))
+	}))
+	resolver := c.Tag.Resolver()
+
+	ids, err := resolver.ResolveIDs("isekai", " ROMANCE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(ids, []string{"ace04997-f6bd-436e-b261-779182193d3d", "423e2eae-a7a2-4a8b-ac03-a8351462d71d"}) {
+		t.Errorf("Unexpected tag ids %v", ids)
+	}
+
+	_, err = resolver.Resolve("isekaii")
+	if !errors.Is(err, ErrUnknownTag) || !strings.Contains(err.Error(), `"Isekai"`) {
+		t.Errorf("Expected unknown tag error suggesting Isekai, got %v", err)
+	}
+	if _, err = resolver.ResolveInGroup(TagGroupGenre, "isekai"); !errors.Is(err, ErrUnknownTag) {
+		t.Errorf("Expected unknown tag error for tag in other group, got %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Expected the tag list to be fetched once, got %d", n)
+	}
+}
+
+//
+// statistics.go
+//
+
+func TestMangaStatistics(t *testing.T) {
+	var batches atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/statistics/manga/single" {
+			w.Write([]byte(`{"result":"ok","statistics":{"single":{"comments":null,"follows":3,
+				"rating":{"average":null,"bayesian":0,"distribution":{"1":0,"10":2}}}}}`))
+			return
+		}
+		batches.Add(1)
+		ids := r.URL.Query()["manga[]"]
+		if len(ids) > StatisticsBatchSize {
+			t.Errorf("Batch of %d ids is too big", len(ids))
+		}
+		statistics := map[string]any{}
+		for _, id := range ids {
+			statistics[id] = map[string]any{"follows": 1, "rating": map[string]any{"average": 7.5, "bayesian": 7.2}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"result": "ok", "statistics": statistics})
+	}), func(o *Options) {
+		o.RateLimit = RateLimit{}
+	})
+
+	single, err := c.Manga.Statistics("single")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := single["single"]; s.Follows != 3 || s.Rating.Average != nil || s.Rating.Distribution[10] != 2 || s.Comments != nil {
+		t.Errorf("Unexpected single statistics %+v", s)
+	}
+
+	ids := make([]string, 250)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+	statistics, err := c.Manga.Statistics(ids...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statistics) != 250 || *statistics["249"].Rating.Average != 7.5 {
+		t.Errorf("Unexpected batch statistics, got %d", len(statistics))
+	}
+	if n := batches.Load(); n != 3 {
+		t.Errorf("Expected 3 batches, got %d", n)
+	}
+}
+
+func TestChapterAndGroupStatistics(t *testing.T) {
+	requested := map[string][]string{}
+	var mu sync.Mutex
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested[r.URL.Path] = append(requested[r.URL.Path], r.URL.RawQuery)
+		mu.Unlock()
+
+		// Single id paths end with the id, batches list their ids under the entity key.
+		ids := r.URL.Query()["chapter[]"]
+		ids = append(ids, r.URL.Query()["group[]"]...)
+		if len(ids) == 0 {
+			ids = []string{path.Base(r.URL.Path)}
+		}
+		statistics := map[string]any{}
+		for _, id := range ids {
+			statistics[id] = map[string]any{"comments": map[string]any{"threadId": 1, "repliesCount": len(id)}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"result": "ok", "statistics": statistics})
+	}))
+
+	if s, err := c.Chapter.Statistics("chapter-a"); err != nil || s["chapter-a"].Comments.RepliesCount != 9 {
+		t.Errorf("Unexpected single chapter statistics %v: %v", s, err)
+	}
+	if s, err := c.Chapter.Statistics("a", "bb"); err != nil || len(s) != 2 || s["bb"].Comments.RepliesCount != 2 {
+		t.Errorf("Unexpected chapter statistics %v: %v", s, err)
+	}
+	if s, err := c.ScanlationGroup.Statistics("group-a"); err != nil || s["group-a"].Comments.RepliesCount != 7 {
+		t.Errorf("Unexpected single group statistics %v: %v", s, err)
+	}
+	if s, err := c.ScanlationGroup.Statistics("a", "bb"); err != nil || len(s) != 2 || s["a"].Comments.RepliesCount != 1 {
+		t.Errorf("Unexpected group statistics %v: %v", s, err)
+	}
+
+	expected := map[string][]string{
+		"/api/statistics/chapter/chapter-a": {""},
+		"/api/statistics/chapter":           {"chapter%5B%5D=a&chapter%5B%5D=bb"},
+		"/api/statistics/group/group-a":     {""},
+		"/api/statistics/group":             {"group%5B%5D=a&group%5B%5D=bb"},
+	}
+	for p, queries := range expected {
+		if !slices.Equal(requested[p], queries) {
+			t.Errorf("Expected %s to be requested with %q, got %q", p, queries, requested[p])
+		}
+	}
+	if len(requested) != len(expected) {
+		t.Errorf("Unexpected requests %v", requested)
+	}
+}
+
 //
 // user.go
 //
@@ -157,3 +581,1060 @@ func TestUser(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+//
+// auth.go
+//
+
+func TestAuth(t *testing.T) {
+	var refreshes atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth" + TokenPath:
+			r.ParseForm()
+			if r.PostForm.Get("client_id") != "client-id" || r.PostForm.Get("client_secret") != "client-secret" {
+				t.Errorf("Missing client credentials: %v", r.PostForm)
+			}
+			switch r.PostForm.Get("grant_type") {
+			case "password":
+				if r.PostForm.Get("password") != "hunter2" {
+					w.WriteHeader(http.StatusUnauthorized)
+					w.Write([]byte(`{"error":"invalid_grant","error_description":"Invalid user credentials"}`))
+					return
+				}
+				// Already expired (within the margin), so it gets refreshed right away.
+				w.Write([]byte(`{"access_token":"access-1","refresh_token":"refresh-1","expires_in":30,"refresh_expires_in":3600}`))
+			case "refresh_token":
+				if r.PostForm.Get("refresh_token") != "refresh-1" {
+					t.Errorf("Unexpected refresh token %q", r.PostForm.Get("refresh_token"))
+				}
+				refreshes.Add(1)
+				w.Write([]byte(`{"access_token":"access-2","expires_in":900}`))
+			}
+		case "/auth" + LogoutPath:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Write([]byte(`{"result":"ok","authorization":"` + r.Header.Get("Authorization") + `"}`))
+		}
+	}))
+
+	authorization := func() string {
+		var res struct{ Authorization string }
+		if err := c.RequestAndDecode(context.Background(), http.MethodGet, c.apiURL("/ping").String(), nil, &res); err != nil {
+			t.Fatal(err)
+		}
+		return res.Authorization
+	}
+
+	if err := c.Auth.Login("user", "wrong"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized for wrong password, got %v", err)
+	}
+	if auth := authorization(); auth != "" || c.Auth.IsLoggedIn() {
+		t.Errorf("Unexpected authorization %q before login", auth)
+	}
+
+	if err := c.Auth.Login("user", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	if auth := authorization(); auth != "Bearer access-2" {
+		t.Errorf("Unexpected authorization %q after login", auth)
+	}
+	authorization()
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("Expected 1 refresh, got %d", n)
+	}
+	// The refresh token is kept when not rotated.
+	if token, _ := c.Auth.Token(); token.RefreshToken != "refresh-1" {
+		t.Errorf("Unexpected refresh token %q", token.RefreshToken)
+	}
+
+	if err := c.Auth.Logout(); err != nil {
+		t.Fatal(err)
+	}
+	if auth := authorization(); auth != "" || c.Auth.IsLoggedIn() {
+		t.Errorf("Unexpected authorization %q after logout", auth)
+	}
+}
+
+func TestIsAPIRequest(t *testing.T) {
+	c := NewDexClient(Options{UserAgent: "test", BaseAPI: "http://host/api"})
+	for raw, expected := range map[string]bool{
+		"http://host/api":            true,
+		"http://host/api/manga":      true,
+		"http://host/apix/manga":     false,
+		"http://host/manga":          false,
+		"http://other/api/manga":     false,
+		"http://host/api-old/manga/": false,
+	} {
+		u, _ := url.Parse(raw)
+		if got := c.isAPIRequest(u); got != expected {
+			t.Errorf("Expected isAPIRequest(%q) to be %t", raw, expected)
+		}
+	}
+}
+
+func TestAuthRevokedSession(t *testing.T) {
+	var logoutFails atomic.Bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth" + TokenPath:
+			r.ParseForm()
+			if r.PostForm.Get("grant_type") == "refresh_token" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"invalid_grant","error_description":"Token is not active"}`))
+				return
+			}
+			w.Write([]byte(`{"access_token":"access","refresh_token":"refresh","expires_in":30,"refresh_expires_in":3600}`))
+		case "/auth" + LogoutPath:
+			if logoutFails.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Write([]byte(`{"result":"ok"}`))
+		}
+	})
+	store := &FileTokenStore{Path: filepath.Join(t.TempDir(), "token.json")}
+	c := newTestClient(t, handler, func(o *Options) { o.TokenStore = store })
+	ping := func() error {
+		return c.RequestAndDecode(context.Background(), http.MethodGet, c.apiURL("/ping").String(), nil, &DexResponse{})
+	}
+
+	if err := c.Auth.Login("user", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := store.Load(context.Background()); err != nil || token == nil || token.RefreshToken != "refresh" {
+		t.Fatalf("Expected token to be persisted, got %+v: %v", token, err)
+	}
+
+	// The rejected refresh fails the current request only, and clears the session.
+	if err := ping(); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized for rejected refresh, got %v", err)
+	}
+	if c.Auth.IsLoggedIn() {
+		t.Error("Expected session to be cleared")
+	}
+	if _, err := os.Stat(store.Path); !os.IsNotExist(err) {
+		t.Errorf("Expected token file to be removed, got %v", err)
+	}
+	if err := ping(); err != nil {
+		t.Errorf("Expected anonymous requests to work again, got %v", err)
+	}
+
+	// Logout clears the session even if the auth server fails.
+	if err := c.Auth.Login("user", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	logoutFails.Store(true)
+	if err := c.Auth.Logout(); err == nil {
+		t.Error("Expected the logout error to be returned")
+	}
+	if token, _ := store.Load(context.Background()); token != nil || c.Auth.IsLoggedIn() {
+		t.Errorf("Expected session to be cleared after failed logout, got %+v", token)
+	}
+}
+
+func TestFileTokenStore(t *testing.T) {
+	ctx := context.Background()
+	store := &FileTokenStore{Path: filepath.Join(t.TempDir(), "token.json")}
+	if token, err := store.Load(ctx); token != nil || err != nil {
+		t.Errorf("Expected no token without file, got %+v: %v", token, err)
+	}
+
+	// A session whose refresh token expired is stale and gets cleared on first use.
+	stale := &Token{
+		AccessToken:   "access",
+		RefreshToken:  "refresh",
+		Expiry:        time.Now().Add(-time.Hour),
+		RefreshExpiry: time.Now().Add(-time.Minute),
+	}
+	if err := store.Save(ctx, stale); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(store.Path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected token file only readable by the user, got %v: %v", info.Mode(), err)
+	}
+	if token, err := store.Load(ctx); err != nil || token.RefreshToken != "refresh" || !token.Expiry.Equal(stale.Expiry) {
+		t.Errorf("Unexpected loaded token %+v: %v", token, err)
+	}
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth"+TokenPath {
+			t.Error("Unexpected refresh of a stale session")
+		}
+		w.Write([]byte(`{"result":"ok"}`))
+	}), func(o *Options) { o.TokenStore = store })
+	if c.Auth.IsLoggedIn() {
+		t.Error("Expected stale session to not be logged in")
+	}
+	err := c.RequestAndDecode(ctx, http.MethodGet, c.apiURL("/ping").String(), nil, &DexResponse{})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized for stale session, got %v", err)
+	}
+	if token, err := store.Load(ctx); token != nil || err != nil {
+		t.Errorf("Expected stale token to be cleared, got %+v: %v", token, err)
+	}
+
+	// Corrupted files are reported by Token, but don't break anonymous requests.
+	os.WriteFile(store.Path, []byte("{"), 0o600)
+	if _, err := store.Load(ctx); err == nil {
+		t.Error("Expected error for corrupted token file")
+	}
+	c = newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("Unexpected authorization without a readable token")
+		}
+		w.Write([]byte(`{"result":"ok"}`))
+	}), func(o *Options) { o.TokenStore = store })
+	if err := c.RequestAndDecode(ctx, http.MethodGet, c.apiURL("/ping").String(), nil, &DexResponse{}); err != nil {
+		t.Errorf("Expected anonymous request with corrupted token file, got %v", err)
+	}
+	if _, err := c.Auth.Token(); err == nil {
+		t.Error("Expected Token to report the corrupted token file")
+	}
+
+	// Saving replaces the file, fixing the mode of existing files.
+	os.Chmod(store.Path, 0o644)
+	if err := store.Save(ctx, stale); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(store.Path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected token file only readable by the user, got %v: %v", info.Mode(), err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(store.Path)); len(entries) != 1 {
+		t.Errorf("Expected temporary files to be cleaned up, got %d files", len(entries))
+	}
+}
+
+//
+// follows.go
+//
+
+func TestFollowIsFollowing(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/user/follows/manga/followed", "/api/user/follows/group/followed":
+			w.Write([]byte(`{"result":"ok"}`))
+		case "/api/manga/followed/follow":
+			if r.Method != http.MethodDelete {
+				t.Errorf("Unexpected method %s for unfollow", r.Method)
+			}
+			w.Write([]byte(`{"result":"ok"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"result":"error","errors":[{"status":404,"title":"Not found"}]}`))
+		}
+	}))
+
+	for id, expected := range map[string]bool{"followed": true, "other": false} {
+		following, err := c.Follow.IsFollowing(RelationshipTypeManga, id)
+		if err != nil || following != expected {
+			t.Errorf("Unexpected follow status for %q: %t, %v", id, following, err)
+		}
+	}
+	if following, err := c.Follow.IsFollowing(RelationshipTypeScanlationGroup, "followed"); err != nil || !following {
+		t.Errorf("Unexpected group follow status: %t, %v", following, err)
+	}
+	if _, err := c.Follow.IsFollowing(RelationshipTypeChapter, "followed"); err == nil {
+		t.Error("Expected error for unfollowable type")
+	}
+	if err := c.Follow.Unfollow(RelationshipTypeManga, "followed"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFollowPages(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/user/follows/group" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		data := []map[string]string{}
+		for i := offset; i < min(offset+2, 3); i++ {
+			data = append(data, map[string]string{"id": strconv.Itoa(i), "type": "scanlation_group"})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"result": "ok", "data": data, "limit": 2, "offset": offset, "total": 3})
+	}))
+
+	page, err := c.Follow.GroupsPage(url.Values{"limit": {"2"}})
+	if err != nil || page.Total != 3 || len(page.Data) != 2 {
+		t.Errorf("Unexpected page %+v: %v", page, err)
+	}
+	all, err := c.Follow.GroupsPager(url.Values{"limit": {"2"}}).All()
+	if err != nil || len(all) != 3 || all[2].Id != "2" {
+		t.Errorf("Unexpected groups %v: %v", all, err)
+	}
+}
+
+//
+// reading_status.go
+//
+
+func TestReadingStatus(t *testing.T) {
+	var set map[string]*ReadingStatus
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/manga/status" && r.URL.Query().Get("status") == "dropped":
+			// MangaDex sends an empty array instead of an empty object.
+			w.Write([]byte(`{"result":"ok","statuses":[]}`))
+		case r.URL.Path == "/api/manga/status":
+			if r.URL.Query().Get("status") != "reading" {
+				t.Errorf("Unexpected query %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"result":"ok","statuses":{"a":"reading","b":"reading"}}`))
+		case r.URL.Path == "/api/manga/a/status" && r.Method == http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&set)
+			w.Write([]byte(`{"result":"ok"}`))
+		case r.URL.Path == "/api/manga/a/status":
+			w.Write([]byte(`{"result":"ok","status":"on_hold"}`))
+		default:
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+	}))
+
+	statuses, err := c.Manga.GetReadingStatuses(ReadingStatusReading)
+	if err != nil || len(statuses) != 2 || statuses["a"] != ReadingStatusReading {
+		t.Errorf("Unexpected statuses %v: %v", statuses, err)
+	}
+	statuses, err = c.Manga.GetReadingStatuses(ReadingStatusDropped)
+	if err != nil || statuses == nil || len(statuses) != 0 {
+		t.Errorf("Expected empty statuses, got %v: %v", statuses, err)
+	}
+	if status, err := c.Manga.GetReadingStatus("a"); err != nil || status != ReadingStatusOnHold {
+		t.Errorf("Unexpected status %q: %v", status, err)
+	}
+	if err = c.Manga.SetReadingStatus("a", ReadingStatusDropped); err != nil || set["status"] == nil || *set["status"] != ReadingStatusDropped {
+		t.Errorf("Unexpected set status %v: %v", set, err)
+	}
+	if err = c.Manga.SetReadingStatus("a", ""); err != nil || set["status"] != nil {
+		t.Errorf("Expected null status to remove from library, got %v: %v", set, err)
+	}
+	if err = c.Manga.SetReadingStatus("a", "reread"); err == nil {
+		t.Error("Expected error for unknown status")
+	}
+}
+
+//
+// rating.go
+//
+
+func TestRatingImport(t *testing.T) {
+	var rated []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/rating" {
+			if ids := r.URL.Query()["manga[]"]; !slices.Equal(ids, []string{"a", "b", "c"}) {
+				t.Errorf("Unexpected ids %v", ids)
+			}
+			w.Write([]byte(`{"result":"ok","ratings":{"a":{"rating":7,"createdAt":"2023-04-01T10:00:00+00:00"}}}`))
+			return
+		}
+		var body map[string]int
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["rating"] < MinRating || body["rating"] > MaxRating {
+			t.Errorf("Unexpected rating %v", body)
+		}
+		rated = append(rated, strings.TrimPrefix(r.URL.Path, "/api/rating/"))
+		w.Write([]byte(`{"result":"ok"}`))
+	}))
+
+	ratings, err := c.Rating.Get("a", "b", "c")
+	if err != nil || ratings["a"].Value != 7 || ratings["a"].CreatedAt.Year() != 2023 {
+		t.Errorf("Unexpected ratings %v: %v", ratings, err)
+	}
+
+	// "a" already has the same score, so it is skipped.
+	ids, err := c.Rating.Import(map[string]int{"a": 7, "b": 10, "c": 1})
+	if err != nil || !slices.Equal(ids, []string{"b", "c"}) || !slices.Equal(rated, ids) {
+		t.Errorf("Unexpected import %v (sent %v): %v", ids, rated, err)
+	}
+
+	rated = nil
+	if _, err = c.Rating.Import(map[string]int{"a": 5, "b": 11}); err == nil || rated != nil {
+		t.Errorf("Expected validation error before sending anything, sent %v", rated)
+	}
+	if err = c.Rating.Set("a", 0); err == nil {
+		t.Error("Expected error for out of range rating")
+	}
+}
+
+func TestRatingImportUnrated(t *testing.T) {
+	var rated atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/rating" {
+			// MangaDex sends an empty array instead of an empty object.
+			w.Write([]byte(`{"result":"ok","ratings":[]}`))
+			return
+		}
+		rated.Add(1)
+		w.Write([]byte(`{"result":"ok"}`))
+	}))
+
+	ratings, err := c.Rating.Get("a")
+	if err != nil || len(ratings) != 0 {
+		t.Errorf("Unexpected ratings %v: %v", ratings, err)
+	}
+	ids, err := c.Rating.Import(map[string]int{"a": 7, "b": 3})
+	if err != nil || len(ids) != 2 || rated.Load() != 2 {
+		t.Errorf("Unexpected import %v: %v", ids, err)
+	}
+}
+
+//
+// downloader.go
+//
+
+// atHomeStandIn: Serve a chapter with the given pages from a stand-in MangaDex@Home node under /home,
+// the contents of each page being its filename. Pages in fail respond with an error.
+func atHomeStandIn(t *testing.T, pages []string, fail ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/at-home/server/chapter-id":
+			json.NewEncoder(w).Encode(AtHomeServerResponse{
+				Result:  "ok",
+				BaseURL: "http://" + r.Host + "/home",
+				Chapter: ChapterData{Hash: "hash", DataSaver: pages},
+			})
+		case strings.HasPrefix(r.URL.Path, "/home/data-saver/hash/"):
+			filename := strings.TrimPrefix(r.URL.Path, "/home/data-saver/hash/")
+			if slices.Contains(fail, filename) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(filename))
+		default:
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+	}
+}
+
+func TestChapterDownloader(t *testing.T) {
+	pages := []string{"1.jpg", "2.jpg", "3.jpg", "4.jpg", "5.jpg"}
+	c := newTestClient(t, atHomeStandIn(t, pages))
+
+	var mu sync.Mutex
+	written := make([]strings.Builder, len(pages))
+	var events []DownloadProgress
+	d := c.AtHome.NewChapterDownloader(ChapterDownloadOptions{
+		Quality:     QualityDataSaver,
+		Concurrency: 2,
+		Progress:    func(p DownloadProgress) { events = append(events, p) },
+	})
+	err := d.Download("chapter-id", func(page ChapterPage) (io.Writer, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return &written[page.Index], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, page := range pages {
+		if written[i].String() != page {
+			t.Errorf("Expected page %d to be %q, got %q", i, page, written[i].String())
+		}
+	}
+	for i, e := range events {
+		if e.Done != i+1 || e.Total != len(pages) || e.Err != nil || e.Bytes != int64(len(e.Page.Filename)) {
+			t.Errorf("Unexpected progress event %+v", e)
+		}
+	}
+	if len(events) != len(pages) {
+		t.Errorf("Expected %d progress events, got %d", len(pages), len(events))
+	}
+}
+
+func TestChapterDownloaderFailure(t *testing.T) {
+	c := newTestClient(t, atHomeStandIn(t, []string{"1.jpg", "2.jpg", "3.jpg"}, "2.jpg"))
+
+	d := c.AtHome.NewChapterDownloader(ChapterDownloadOptions{Quality: QualityDataSaver, Concurrency: 1, PageAttempts: 1})
+	err := d.Download("chapter-id", func(page ChapterPage) (io.Writer, error) {
+		return io.Discard, nil
+	})
+	if !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "page 1 (2.jpg)") {
+		t.Errorf("Expected not found error for the second page, got %v", err)
+	}
+}
+
+func TestChapterDownloaderFailover(t *testing.T) {
+	pages := []string{"1.jpg", "2.jpg", "3.jpg"}
+	var acquisitions, brokenHits atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/at-home/server/chapter-id":
+			if r.URL.Query().Get("forcePort443") != "true" {
+				t.Errorf("Expected forcePort443, got %q", r.URL.RawQuery)
+			}
+			// The first node is broken, the second one works.
+			node := "/broken"
+			if acquisitions.Add(1) > 1 {
+				node = "/home"
+			}
+			json.NewEncoder(w).Encode(AtHomeServerResponse{
+				Result:  "ok",
+				BaseURL: "http://" + r.Host + node,
+				Chapter: ChapterData{Hash: "hash", Data: pages},
+			})
+		case strings.HasPrefix(r.URL.Path, "/home/data/hash/"):
+			w.Write([]byte(strings.TrimPrefix(r.URL.Path, "/home/data/hash/")))
+		default:
+			brokenHits.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+
+	d := c.AtHome.NewChapterDownloader(ChapterDownloadOptions{ForcePort443: true, Concurrency: 1})
+	if err := d.Download("chapter-id", func(page ChapterPage) (io.Writer, error) { return io.Discard, nil }); err != nil {
+		t.Fatal(err)
+	}
+	if acquisitions.Load() != 2 {
+		t.Errorf("Expected the server to be requested again once, got %d requests", acquisitions.Load())
+	}
+	// The client's retry policy doesn't apply, the broken node is replaced after its first failure.
+	if brokenHits.Load() != 1 {
+		t.Errorf("Expected a single request to the broken node, got %d", brokenHits.Load())
+	}
+}
+
+func TestChapterDownloaderUploadsFallback(t *testing.T) {
+	pages := []string{"1.jpg", "2.jpg", "3.jpg"}
+	var acquisitions atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/at-home/server/chapter-id":
+			acquisitions.Add(1)
+			json.NewEncoder(w).Encode(AtHomeServerResponse{
+				Result:  "ok",
+				BaseURL: "http://" + r.Host + "/broken",
+				Chapter: ChapterData{Hash: "hash", Data: pages},
+			})
+		case strings.HasPrefix(r.URL.Path, "/uploads/data/hash/"):
+			w.Write([]byte(strings.TrimPrefix(r.URL.Path, "/uploads/data/hash/")))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}), func(o *Options) {
+		o.BaseUploads = strings.TrimSuffix(o.BaseAPI, "/api") + "/uploads"
+	})
+
+	written := make([]strings.Builder, len(pages))
+	d := c.AtHome.NewChapterDownloader(ChapterDownloadOptions{
+		Concurrency:        2,
+		PageAttempts:       5,
+		MaxServerRefreshes: 1,
+		FallbackAfter:      -1,
+	})
+	err := d.Download("chapter-id", func(page ChapterPage) (io.Writer, error) {
+		written[page.Index].Reset()
+		return &written[page.Index], nil
+	})
+	if err == nil {
+		t.Fatal("Expected error when falling back is disabled")
+	}
+
+	// Once the refreshes are exhausted the uploads server is used.
+	acquisitions.Store(0)
+	d = c.AtHome.NewChapterDownloader(ChapterDownloadOptions{Concurrency: 2, PageAttempts: 5, MaxServerRefreshes: 1})
+	err = d.Download("chapter-id", func(page ChapterPage) (io.Writer, error) {
+		written[page.Index].Reset()
+		return &written[page.Index], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, page := range pages {
+		if written[i].String() != page {
+			t.Errorf("Expected page %d to be %q, got %q", i, page, written[i].String())
+		}
+	}
+	if acquisitions.Load() != 2 {
+		t.Errorf("Expected the server to be requested at most twice, got %d requests", acquisitions.Load())
+	}
+}
+
+//
+// header.go
+//
+
+func TestConcurrentRequests(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth"+TokenPath {
+			// Always about to expire, so every request races to refresh it.
+			w.Write([]byte(`{"access_token":"access","refresh_token":"refresh","expires_in":30}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"result":        "ok",
+			"worker":        r.Header.Get("X-Worker"),
+			"authorization": r.Header.Get("Authorization"),
+		})
+	}), func(o *Options) {
+		o.RateLimit = RateLimit{}
+	})
+	if err := c.Auth.Login("user", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker := strconv.Itoa(i)
+			ctx := WithHeader(context.Background(), http.Header{"X-Worker": {worker}})
+			for j := 0; j < 20; j++ {
+				// Mutate the common headers while other requests are in flight.
+				c.SetHeader("X-Request", worker)
+
+				var res struct{ Worker, Authorization string }
+				if err := c.RequestAndDecode(ctx, http.MethodGet, c.apiURL("/ping").String(), nil, &res); err != nil {
+					t.Error(err)
+					return
+				}
+				if res.Worker != worker || res.Authorization != "Bearer access" {
+					t.Errorf("Worker %s got headers meant for %q (%q)", worker, res.Worker, res.Authorization)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Overrides don't leak into the common headers.
+	if worker := c.Header().Get("X-Worker"); worker != "" {
+		t.Errorf("Per-request header leaked into the common headers: %q", worker)
+	}
+}
+
+//
+// middleware.go
+//
+
+func TestMiddleware(t *testing.T) {
+	var order []string
+	var injected atomic.Bool
+	faulty := &http.Client{
+		// Fail the first request as if MangaDex was down.
+		Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if !injected.Swap(true) {
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader("")),
+					Request:    req,
+				}, nil
+			}
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
+	named := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":"ok"}`))
+	}), func(o *Options) {
+		o.HTTPClient = faulty
+		o.Middleware = []Middleware{named("outer"), named("inner")}
+	})
+
+	if _, err := c.Request(context.Background(), http.MethodGet, c.apiURL("/ping").String(), nil); err != nil {
+		t.Fatal(err)
+	}
+	// Both attempts go through the chain, outermost first.
+	if expected := []string{"outer", "inner", "outer", "inner"}; !slices.Equal(order, expected) {
+		t.Errorf("Unexpected middleware calls %v, wanted %v", order, expected)
+	}
+	if c.client == faulty {
+		t.Error("The given HTTPClient wasn't copied")
+	}
+}
+
+func TestMiddlewareBareResponse(t *testing.T) {
+	// Transports aren't required to set the response's request.
+	bare := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		})
+	}
+	c := newTestClient(t, http.NotFoundHandler(), func(o *Options) {
+		o.Middleware = []Middleware{bare}
+	})
+
+	u := c.apiURL("/ping").String()
+	_, err := c.Request(context.Background(), http.MethodGet, u, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrMaintenance) {
+		t.Fatalf("Expected maintenance APIError, got %v", err)
+	}
+	if apiErr.Method != http.MethodGet || apiErr.URL != u {
+		t.Errorf("Unexpected request in error: %s %s", apiErr.Method, apiErr.URL)
+	}
+}
+
+//
+// ratelimit.go
+//
+
+func TestRateLimiter(t *testing.T) {
+	// Within a long period the refill during the test is negligible, so the durations are predictable.
+	b := newBucket(RateLimit{Requests: 2, Period: time.Hour})
+	near := func(d, expected time.Duration) bool {
+		return d >= expected-time.Second && d <= expected
+	}
+
+	for i := 0; i < 2; i++ {
+		if d := b.reserve(); d != 0 {
+			t.Errorf("Expected token %d to be available, wait %s", i, d)
+		}
+	}
+	// Each token takes half an hour to refill, and reservations queue up.
+	if d := b.reserve(); !near(d, 30*time.Minute) {
+		t.Errorf("Expected to wait 30m for the third token, got %s", d)
+	}
+	if d := b.reserve(); !near(d, time.Hour) {
+		t.Errorf("Expected to wait 1h for the fourth token, got %s", d)
+	}
+	// Released tokens shorten the queue again.
+	b.release()
+	if d := b.reserve(); !near(d, time.Hour) {
+		t.Errorf("Expected released token to be reused, wait %s", d)
+	}
+
+	// Routes match path templates segment by segment.
+	limiter := newRateLimiter(RateLimit{}, map[string]RateLimit{GetMDHomeURLPath: {Requests: 1, Period: time.Hour}})
+	for path, expected := range map[string]bool{
+		"/at-home/server/some-id":       true,
+		"/at-home/server":               false,
+		"/at-home/server/some-id/extra": false,
+		"/manga/some-id":                false,
+	} {
+		if matched := limiter.routes[0].match(strings.Split(strings.Trim(path, "/"), "/")); matched != expected {
+			t.Errorf("Expected route match %t for %q", expected, path)
+		}
+	}
+
+	// Waiting is cancelled with the context, without using up a token.
+	ctx := context.Background()
+	if err := limiter.Wait(ctx, "/manga"); err != nil {
+		t.Fatal(err)
+	}
+	if err := limiter.Wait(ctx, "/at-home/server/some-id"); err != nil {
+		t.Fatal(err)
+	}
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := limiter.Wait(cctx, "/at-home/server/some-id"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context error while waiting for rate limit, got %v", err)
+	}
+	if d := limiter.routes[0].bucket.reserve(); !near(d, time.Hour) {
+		t.Errorf("Expected cancelled wait to release its token, wait %s", d)
+	}
+
+	// A route token is given back when the global wait fails afterwards.
+	limiter = newRateLimiter(RateLimit{Requests: 1, Period: time.Hour}, map[string]RateLimit{GetMDHomeURLPath: {Requests: 2, Period: time.Hour}})
+	if err := limiter.Wait(ctx, "/manga"); err != nil {
+		t.Fatal(err)
+	}
+	if err := limiter.Wait(cctx, "/at-home/server/some-id"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context error while waiting for global rate limit, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if d := limiter.routes[0].bucket.reserve(); d != 0 {
+			t.Errorf("Expected route token %d to be released, wait %s", i, d)
+		}
+	}
+}
+
+//
+// retry.go
+//
+
+func TestRetry(t *testing.T) {
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"result":"ok"}`))
+	}))
+	defer ts.Close()
+
+	options := DefaultOptions()
+	options.Retry.MinBackoff = time.Millisecond
+	c := NewDexClient(options)
+
+	// Recovers on the third attempt.
+	var res DexResponse
+	if err := c.RequestAndDecode(context.Background(), http.MethodGet, ts.URL, nil, &res); err != nil {
+		t.Fatalf("Request failed after %d attempts: %s", attempts.Load(), err.Error())
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("Expected 3 attempts, got %d", n)
+	}
+
+	// Gives up once the attempts run out.
+	attempts.Store(-10)
+	if _, err := c.Request(context.Background(), http.MethodGet, ts.URL, nil); err == nil {
+		t.Error("Expected error after running out of attempts")
+	}
+	if n := attempts.Load(); n != -7 {
+		t.Errorf("Expected 3 attempts, got %d", n+10)
+	}
+
+	// Non-idempotent requests are never retried.
+	attempts.Store(0)
+	if _, err := c.Request(context.Background(), http.MethodPost, ts.URL, nil); err == nil {
+		t.Error("Expected error for POST request")
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("Expected 1 attempt, got %d", n)
+	}
+
+	// Transport errors are retried, errors preparing the request are not.
+	var sent atomic.Int32
+	c = NewDexClient(options)
+	c.client = &http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent.Add(1)
+		return nil, errors.New("connection reset")
+	})}
+	if _, err := c.Request(context.Background(), http.MethodGet, ts.URL, nil); err == nil || sent.Load() != 3 {
+		t.Errorf("Expected 3 attempts on transport errors, got %d: %v", sent.Load(), err)
+	}
+	if _, err := c.Request(context.Background(), http.MethodGet, "http://[::1", nil); err == nil || strings.Contains(err.Error(), "attempts") {
+		t.Errorf("Expected invalid URL to fail without retrying, got %v", err)
+	}
+
+	// Writes are only retried when the server didn't process them.
+	for status, expected := range map[int]int32{http.StatusBadGateway: 1, http.StatusServiceUnavailable: 3} {
+		var deletes atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			deletes.Add(1)
+			w.WriteHeader(status)
+		}))
+		c = NewDexClient(options)
+		c.Request(context.Background(), http.MethodDelete, ts.URL, nil)
+		ts.Close()
+		if n := deletes.Load(); n != expected {
+			t.Errorf("Expected %d DELETE attempts on %d, got %d", expected, status, n)
+		}
+	}
+
+	// The last error is kept when the context ends before the retry.
+	limited := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer limited.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := c.Request(ctx, http.MethodGet, limited.URL, nil); !errors.Is(err, ErrRateLimited) || !strings.Contains(err.Error(), "1 attempts") {
+		t.Errorf("Expected rate limit error without waiting past the deadline, got %v", err)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err := c.Request(ctx, http.MethodGet, limited.URL, nil); !errors.Is(err, ErrRateLimited) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected both rate limit and context errors, got %v", err)
+	}
+}
+
+//
+// custom_list.go
+//
+
+func TestCustomListCreate(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body CustomListBody
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.Method != http.MethodPost || r.URL.Path != "/api/list" || body.Name != "Favs" || body.Visibility != CustomListVisibilityPrivate {
+			t.Errorf("Unexpected request %s %s %+v", r.Method, r.URL.Path, body)
+		}
+		w.Write([]byte(`{"result":"ok","response":"entity","data":{"id":"list","type":"custom_list",
+			"attributes":{"name":"Favs","visibility":"private","version":1},
+			"relationships":[{"id":"00000000-0000-0000-0000-00000000000a","type":"manga"},{"id":"00000000-0000-0000-0000-00000000000b","type":"user"}]}}`))
+	}))
+
+	list, err := c.CustomList.Create(CustomListBody{
+		Name:       "Favs",
+		Visibility: CustomListVisibilityPrivate,
+		Manga:      &[]string{"00000000-0000-0000-0000-00000000000a"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := list.GetMangaIDs(); len(ids) != 1 || list.Attributes.Version != 1 {
+		t.Errorf("Unexpected custom list %+v", list)
+	}
+
+	if _, err := c.CustomList.Update("list", CustomListBody{Name: "Favs"}); err == nil {
+		t.Error("Expected error for update without version")
+	}
+}
+
+func TestCustomListUpdateManga(t *testing.T) {
+	var body map[string]json.RawMessage
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"result":"ok","data":{"id":"list","type":"custom_list","attributes":{"version":2}}}`))
+	}))
+
+	// An empty list of manga clears the list.
+	if _, err := c.CustomList.Update("list", CustomListBody{Manga: &[]string{}, Version: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if string(body["manga"]) != "[]" {
+		t.Errorf("Expected manga to be cleared, got %s", body["manga"])
+	}
+	var cleared []string
+	if _, err := c.CustomList.Update("list", CustomListBody{Manga: &cleared, Version: 1}); err != nil || string(body["manga"]) != "[]" {
+		t.Errorf("Expected nil slice to clear manga too, got %s: %v", body["manga"], err)
+	}
+
+	// Without manga they are left unchanged.
+	if _, err := c.CustomList.Update("list", CustomListBody{Name: "Renamed", Version: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := body["manga"]; ok {
+		t.Errorf("Expected manga to be left out, got %s", body["manga"])
+	}
+}
+
+func TestCustomListPages(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/user/some-user/list" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		data := []map[string]string{}
+		for i := offset; i < min(offset+2, 3); i++ {
+			data = append(data, map[string]string{"id": strconv.Itoa(i), "type": "custom_list"})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"result": "ok", "data": data, "limit": 2, "offset": offset, "total": 3})
+	}))
+
+	page, err := c.CustomList.ListForUserPage("some-user", url.Values{"limit": {"2"}})
+	if err != nil || page.Total != 3 || len(page.Data) != 2 || !page.HasMore() {
+		t.Errorf("Unexpected page %+v: %v", page, err)
+	}
+	all, err := c.CustomList.ListForUserPager("some-user", url.Values{"limit": {"2"}}).All()
+	if err != nil || len(all) != 3 || all[2].ID != "2" {
+		t.Errorf("Unexpected lists %v: %v", all, err)
+	}
+}
+
+//
+// error.go
+//
+
+func TestAPIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.Header().Set("X-RateLimit-Remaining", "4")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"result":"error","errors":[{"status":404,"title":"Not found","detail":"Manga could not be found"}]}`))
+		default:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`<html>Too many requests</html>`))
+		}
+	}))
+	defer ts.Close()
+
+	options := DefaultOptions()
+	options.Retry.MinBackoff = time.Millisecond
+	c := NewDexClient(options)
+
+	_, err := c.Request(context.Background(), http.MethodGet, ts.URL+"/missing", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError, got %v", err)
+	}
+	if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrRateLimited) {
+		t.Errorf("Unexpected sentinel match for %v", err)
+	}
+	if len(apiErr.Errors) != 1 || apiErr.Errors[0].Detail != "Manga could not be found" {
+		t.Errorf("Unexpected decoded errors %+v", apiErr.Errors)
+	}
+	if apiErr.RateLimitRemaining != 4 || apiErr.RateLimitLimit != -1 {
+		t.Errorf("Unexpected rate limit headers %d/%d", apiErr.RateLimitRemaining, apiErr.RateLimitLimit)
+	}
+
+	// Still matches after exhausting the retries.
+	_, err = c.Request(context.Background(), http.MethodGet, ts.URL+"/limited", nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+}
+
+//
+// pagination.go
+//
+
+// listServer: Serve a list endpoint with total results whose data are the result indices.
+func listServer(t *testing.T, total int, requests *[]url.Values) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		*requests = append(*requests, q)
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		if offset+limit > MaxListResults {
+			t.Errorf("Requested past the results ceiling: offset %d limit %d", offset, limit)
+		}
+		data := []int{}
+		for i := offset; i < min(offset+limit, total); i++ {
+			data = append(data, i)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"result": "ok", "data": data, "limit": limit, "offset": offset, "total": total,
+		})
+	}))
+}
+
+func TestPager(t *testing.T) {
+	var requests []url.Values
+	ts := listServer(t, 250, &requests)
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+
+	pager := newPager[int](context.Background(), client, *u, url.Values{"limit": {"100"}})
+	if total, err := pager.Total(); err != nil || total != 250 {
+		t.Errorf("Unexpected total %d: %v", total, err)
+	}
+	all, err := pager.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 250 || all[0] != 0 || all[249] != 249 {
+		t.Errorf("Unexpected results: %d items", len(all))
+	}
+	if len(requests) != 3 {
+		t.Errorf("Expected 3 page requests, got %d", len(requests))
+	}
+}
+
+func TestPagerResultsCeiling(t *testing.T) {
+	var requests []url.Values
+	ts := listServer(t, 20000, &requests)
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+
+	pager := newPager[int](context.Background(), client, *u, url.Values{"offset": {"9950"}})
+	all, err := pager.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 50 || len(requests) != 1 {
+		t.Errorf("Expected 50 results in 1 request, got %d in %d", len(all), len(requests))
+	}
+}
@@ -15,6 +15,15 @@ import (
 const (
 	GetMDHomeURLPath = "/at-home/server/%s"
 	MDHomeReportURL  = "https://api.mangadex.network/report"
+	BaseUploads      = "https://uploads.mangadex.org"
+
+	// AtHomeServerTTL: How long an MD@Home server assignment stays valid, its token expires afterwards.
+	AtHomeServerTTL = 15 * time.Minute
+
+	// QualityData: Original quality chapter pages.
+	QualityData = "data"
+	// QualityDataSaver: Compressed chapter pages.
+	QualityDataSaver = "data-saver"
 )
 
 // AtHomeService: Provides MangaDex@Home services provided by the API.
@@ -38,6 +47,13 @@ type ChapterData struct {
 type AtHomeServer struct {
 	client *DexClient
 
+	// Where the assignment came from, so it can be requested again.
+	chapterID string
+	params    url.Values
+	acquired  time.Time
+	// fallback: Whether this is the uploads server instead of an MD@Home node, which must not be reported.
+	fallback bool
+
 	BaseURL string
 	Chapter ChapterData
 }
@@ -45,76 +61,136 @@ type AtHomeServer struct {
 // Get: Get MangaDex@Home server for a chapter by id.
 //
 // https://api.mangadex.org/docs/redoc.html#tag/AtHome/operation/get-at-home-server-chapterId
-func (s *AtHomeService) Get(id string, params url.Values) (atHome *AtHomeServer, err error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = fmt.Sprintf(GetMDHomeURLPath, id)
+func (s *AtHomeService) Get(id string, params url.Values) (*AtHomeServer, error) {
+	return s.GetContext(context.Background(), id, params)
+}
+
+// GetContext: Get with custom context.
+func (s *AtHomeService) GetContext(ctx context.Context, id string, params url.Values) (atHome *AtHomeServer, err error) {
+	u := s.client.apiURL(fmt.Sprintf(GetMDHomeURLPath, id))
 	u.RawQuery = params.Encode()
 
 	var res AtHomeServerResponse
-	err = s.client.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil, &res)
+	err = s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
 	if err != nil {
 		return nil, err
 	}
 
 	atHome = &AtHomeServer{
-		client:  s.client,
-		BaseURL: res.BaseURL,
-		Chapter: res.Chapter,
+		client:    s.client,
+		chapterID: id,
+		params:    params,
+		acquired:  time.Now(),
+		BaseURL:   res.BaseURL,
+		Chapter:   res.Chapter,
 	}
 	return atHome, nil
 }
 
+// Stale: Whether the server assignment is older than AtHomeServerTTL and should be requested again.
+func (s *AtHomeServer) Stale() bool {
+	return time.Since(s.acquired) > AtHomeServerTTL
+}
+
+// uploadsFallback: Get a copy of the server that fetches the pages from the uploads server instead.
+func (s *AtHomeServer) uploadsFallback() *AtHomeServer {
+	fallback := *s
+	fallback.BaseURL = s.client.baseUploads
+	fallback.fallback = true
+	return &fallback
+}
+
+// Pages: Filenames of the chapter's pages in order for the quality, nil if the quality is unknown.
+func (c *ChapterData) Pages(quality string) []string {
+	switch quality {
+	case QualityData:
+		return c.Data
+	case QualityDataSaver:
+		return c.DataSaver
+	default:
+		return nil
+	}
+}
+
 // GetChapterPage: Return page data for a chapter with the filename of that page.
 func (s *AtHomeServer) GetChapterPage(quality, filename string, report bool) ([]byte, error) {
-	var finalErr error
+	return s.GetChapterPageContext(context.Background(), quality, filename, report)
+}
+
+// GetChapterPageContext: GetChapterPage with custom context.
+//
+// The report (if any) is sent in the background and is not cancelled along with ctx.
+func (s *AtHomeServer) GetChapterPageContext(ctx context.Context, quality, filename string, report bool) ([]byte, error) {
+	var image bytes.Buffer
+	if _, err := s.WriteChapterPageContext(ctx, quality, filename, &image, report); err != nil {
+		return nil, err
+	}
+	return image.Bytes(), nil
+}
+
+// WriteChapterPage: Stream page data for a chapter with the filename of that page to w,
+// returning the amount of bytes written.
+func (s *AtHomeServer) WriteChapterPage(quality, filename string, w io.Writer, report bool) (int64, error) {
+	return s.WriteChapterPageContext(context.Background(), quality, filename, w, report)
+}
+
+// WriteChapterPageContext: WriteChapterPage with custom context.
+//
+// The report (if any) is sent in the background and is not cancelled along with ctx.
+// Downloads from the uploads server fallback are never reported.
+func (s *AtHomeServer) WriteChapterPageContext(ctx context.Context, quality, filename string, w io.Writer, report bool) (n int64, err error) {
+	return s.writeChapterPage(ctx, quality, filename, w, report, s.client.Request)
+}
+
+// writeChapterPage: Stream the page to w, sending the request with send.
+func (s *AtHomeServer) writeChapterPage(ctx context.Context, quality, filename string, w io.Writer, report bool,
+	send func(ctx context.Context, method, url string, body io.Reader) (*http.Response, error)) (n int64, err error) {
 	url := strings.Join([]string{s.BaseURL, quality, s.Chapter.Hash, filename}, "/")
-	ctx := context.Background()
 
 	// Start timing how long to get all bytes for the file.
 	start := time.Now()
-	resp, err := s.client.Request(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		finalErr = fmt.Errorf("Failed to get chapter page data: %s", err.Error())
-		if resp == nil || resp.Body == nil {
-			return nil, finalErr
-		}
+	var cached bool
+	if report && !s.fallback {
+		defer func() {
+			s.report(ctx, &reportPayload{
+				URL:      url,
+				Success:  err == nil,
+				Bytes:    n,
+				Duration: time.Since(start).Milliseconds(),
+				Cached:   cached,
+			})
+		}()
 	}
-	defer resp.Body.Close()
 
-	// Even on failed requests, get all the bytes from the body
-	image, err := io.ReadAll(resp.Body)
+	resp, err := send(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		finalErr = fmt.Errorf("Failed to read all bytes from body: %s", finalErr.Error())
+		return 0, fmt.Errorf("Failed to get chapter page data: %w", err)
 	}
+	defer resp.Body.Close()
+	cached = strings.HasPrefix(resp.Header.Get("X-Cache"), "HIT")
 
-	// Send report in the background.
-	if report {
-		go func() {
-			r := &reportPayload{
-				URL:      url,
-				Success:  finalErr == nil,
-				Bytes:    len(image),
-				Duration: time.Since(start).Milliseconds(),
-				Cached:   strings.HasPrefix(resp.Header.Get("X-Cache"), "HIT"),
-			}
-			rBytes, err := json.Marshal(r)
-			if err == nil {
-				s.client.Request(ctx, http.MethodPost, MDHomeReportURL, bytes.NewBuffer(rBytes))
-			}
-		}()
+	n, err = io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("Failed to read all bytes from body: %w", err)
 	}
+	return n, nil
+}
 
-	if finalErr != nil {
-		return nil, finalErr
-	}
-	return image, nil
+// report: Send a page download report in the background.
+func (s *AtHomeServer) report(ctx context.Context, r *reportPayload) {
+	go func() {
+		rBytes, err := json.Marshal(r)
+		if err == nil {
+			s.client.Request(context.WithoutCancel(ctx), http.MethodPost, s.client.mdHomeReportURL, bytes.NewBuffer(rBytes))
+		}
+	}()
 }
 
 // reportPayload: Required fields for reporting page download result.
 type reportPayload struct {
-	URL      string
-	Success  bool
-	Bytes    int
-	Duration int64
-	Cached   bool
+	URL      string `json:"url"`
+	Success  bool   `json:"success"`
+	Bytes    int64  `json:"bytes"`
+	Duration int64  `json:"duration"`
+	Cached   bool   `json:"cached"`
 }
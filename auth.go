@@ -1,136 +1,385 @@
 package mangodex
 
-/*
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
 const (
-	LoginPath        = "/auth/login"
-	LogoutPath       = "/auth/logout"
-	RefreshTokenPath = "/auth/refresh"
+	BaseAuth   = "https://auth.mangadex.org"
+	TokenPath  = "/realms/mangadex/protocol/openid-connect/token"
+	LogoutPath = "/realms/mangadex/protocol/openid-connect/logout"
+
+	// tokenExpiryMargin: Refresh the access token when it is this close to expiring.
+	tokenExpiryMargin = time.Minute
 )
-*/
 
-// AuthService: Provides auth services provided by the API.
+// AuthService: Provides authentication through personal API clients.
 //
-// Deprecated: Authentication is now done by registering a personal client and using an auth-token for all requests that support it.
-// https://api.mangadex.org/docs/02-authentication/personal-clients/.
+// Once logged in, every API request is authorized with the session's access token,
+// which is refreshed automatically before it expires.
 //
-// Will add API functionality later. Commented code is heavily outdated.
+// https://api.mangadex.org/docs/02-authentication/personal-clients/
 type AuthService service
 
-/*
-// AuthResponse: Typical AuthService response.
-type AuthResponse struct {
-	Result  string  `json:"result"`
-	Token   token   `json:"token"`
-	Message *string `json:"message,omitempty"`
+// Token: OAuth2 tokens of a logged in session.
+type Token struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken"`
+	Expiry       time.Time `json:"expiry"`
+	// RefreshExpiry: When the refresh token expires, zero if it doesn't.
+	RefreshExpiry time.Time `json:"refreshExpiry"`
 }
 
-func (ar AuthResponse) GetResult() string {
-	return ar.Result
+// expired: Whether the access token is expired, or about to.
+func (t *Token) expired() bool {
+	return time.Until(t.Expiry) < tokenExpiryMargin
 }
 
-// token: MangaDex token. Includes session and refresh token.
-type token struct {
-	Session string `json:"session"`
-	Refresh string `json:"refresh"`
+// refreshExpired: Whether the refresh token is expired.
+func (t *Token) refreshExpired() bool {
+	return !t.RefreshExpiry.IsZero() && time.Now().After(t.RefreshExpiry)
 }
 
-// Login: Login to MangaDex.
-// https://api.mangadex.org/docs.html#operation/post-auth-login
-func (s *AuthService) Login(user, pwd string) error {
-	return s.LoginContext(context.Background(), user, pwd)
+// TokenStore: Persists the session token, so that sessions survive restarts.
+//
+// Implementations must be safe for concurrent use.
+type TokenStore interface {
+	// Load: Get the stored token, nil if there is none.
+	Load(ctx context.Context) (*Token, error)
+	// Save: Store the token, nil clears it.
+	Save(ctx context.Context, token *Token) error
 }
 
-// LoginContext: Login with custom context.
-func (s *AuthService) LoginContext(ctx context.Context, user, pwd string) error {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = LoginPath
+// MemoryTokenStore: Keeps the token in memory only, the default TokenStore.
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *Token
+}
 
-	// Create required request body.
-	req := map[string]string{
-		"username": user,
-		"password": pwd,
+func (s *MemoryTokenStore) Load(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token, nil
+}
+
+func (s *MemoryTokenStore) Save(ctx context.Context, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	return nil
+}
+
+// FileTokenStore: Keeps the token as JSON in a file only readable by the current user.
+type FileTokenStore struct {
+	mu   sync.Mutex
+	Path string
+}
+
+func (s *FileTokenStore) Load(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
 	}
-	rBytes, err := json.Marshal(&req)
+	if err != nil {
+		return nil, err
+	}
+	var token Token
+	if err = json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("Failed to decode token file %q: %s", s.Path, err.Error())
+	}
+	return &token, nil
+}
+
+func (s *FileTokenStore) Save(ctx context.Context, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if token == nil {
+		if err := os.Remove(s.Path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.Marshal(token)
 	if err != nil {
 		return err
 	}
 
-	var ar AuthResponse
-	if err = s.client.RequestAndDecode(ctx, http.MethodPost, u.String(), bytes.NewBuffer(rBytes), &ar); err != nil {
+	// Write to a temporary file (created with 0600) and move it over, so the file
+	// is never left half-written and doesn't keep the mode of an existing file.
+	f, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
 		return err
 	}
+	return os.Rename(f.Name(), s.Path)
+}
+
+// session: Authentication state of a DexClient, safe for concurrent use.
+type session struct {
+	mu     sync.Mutex
+	store  TokenStore
+	token  *Token
+	loaded bool // Whether the token was already loaded from the store.
+}
 
-	// Set client token and header for authorization.
-	s.SetRefreshToken(ar.Token.Refresh)
-	s.client.header.Set("Authorization", fmt.Sprintf("Bearer %s", ar.Token.Session))
+// load: Load the token from the store the first time it is needed, mu must be held.
+func (s *session) load(ctx context.Context) error {
+	if s.loaded {
+		return nil
+	}
+	token, err := s.store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to load token: %s", err.Error())
+	}
+	s.token = token
+	s.loaded = true
 	return nil
 }
 
-// Logout: Logout of MangaDex and invalidates all tokens.
-// https://api.mangadex.org/docs.html#operation/post-auth-logout
+// set: Replace the token and persist it, mu must be held.
+func (s *session) set(ctx context.Context, token *Token) error {
+	s.token = token
+	s.loaded = true
+	if err := s.store.Save(ctx, token); err != nil {
+		return fmt.Errorf("Failed to save token: %s", err.Error())
+	}
+	return nil
+}
+
+// tokenResponse: Response of the OAuth2 token endpoint.
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshExpiresIn int    `json:"refresh_expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Login: Login with the client's personal API client credentials and the user's credentials.
+//
+// https://api.mangadex.org/docs/02-authentication/personal-clients/
+func (s *AuthService) Login(username, password string) error {
+	return s.LoginContext(context.Background(), username, password)
+}
+
+// LoginContext: Login with custom context.
+func (s *AuthService) LoginContext(ctx context.Context, username, password string) error {
+	sess := s.client.session
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	token, err := s.requestToken(ctx, url.Values{
+		"grant_type": {"password"},
+		"username":   {username},
+		"password":   {password},
+	})
+	if err != nil {
+		return err
+	}
+	return sess.set(ctx, token)
+}
+
+// Logout: Logout of MangaDex, invalidating the session's tokens.
+//
+// The local session is cleared even if the auth server fails to invalidate the tokens.
 func (s *AuthService) Logout() error {
 	return s.LogoutContext(context.Background())
 }
 
 // LogoutContext: Logout with custom context.
 func (s *AuthService) LogoutContext(ctx context.Context) error {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = LogoutPath
+	sess := s.client.session
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
 
-	var r Response
-	if err := s.client.RequestAndDecode(ctx, http.MethodPost, u.String(), nil, &r); err != nil {
+	if err := sess.load(ctx); err != nil {
 		return err
 	}
+	if sess.token == nil {
+		return nil
+	}
+	err := s.postForm(ctx, LogoutPath, url.Values{"refresh_token": {sess.token.RefreshToken}}, nil)
+	// Forget the session even if the server failed to end it.
+	if serr := sess.set(ctx, nil); serr != nil {
+		return serr
+	}
+	return err
+}
 
-	// Remove the stored client token and also authorization header.
-	s.SetRefreshToken("")
-	s.client.header.Del("Authorization")
-	return nil
+// Refresh: Refresh the access token now, regardless of its expiry.
+func (s *AuthService) Refresh() error {
+	return s.RefreshContext(context.Background())
 }
 
-// RefreshSessionToken: Refresh session token using refresh token.
-// https://api.mangadex.org/docs.html#operation/post-auth-refresh
-func (s *AuthService) RefreshSessionToken() error {
-	return s.RefreshSessionTokenContext(context.Background())
+// RefreshContext: Refresh with custom context.
+func (s *AuthService) RefreshContext(ctx context.Context) error {
+	sess := s.client.session
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	if err := sess.load(ctx); err != nil {
+		return err
+	}
+	return s.refresh(ctx)
 }
 
-// RefreshSessionTokenContext: refreshToken with custom context.
-func (s *AuthService) RefreshSessionTokenContext(ctx context.Context) error {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = RefreshTokenPath
+// refresh: Refresh the session's access token, session.mu must be held.
+func (s *AuthService) refresh(ctx context.Context) error {
+	sess := s.client.session
+	if sess.token == nil {
+		return fmt.Errorf("%w: not logged in", ErrUnauthorized)
+	}
+	if sess.token.refreshExpired() {
+		if err := sess.set(ctx, nil); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: session expired, login again", ErrUnauthorized)
+	}
 
-	// Create required request body.
-	req := map[string]string{
-		"token": s.client.refreshToken,
+	token, err := s.requestToken(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {sess.token.RefreshToken},
+	})
+	if errors.Is(err, ErrUnauthorized) {
+		// The refresh token was revoked or rejected, so the session is dead for good.
+		if serr := sess.set(ctx, nil); serr != nil {
+			return serr
+		}
+		return fmt.Errorf("%w, login again", err)
 	}
-	rBytes, err := json.Marshal(&req)
 	if err != nil {
 		return err
 	}
-
-	var ar AuthResponse
-	if err = s.client.RequestAndDecode(ctx, http.MethodPost, u.String(), bytes.NewBuffer(rBytes), &ar); err != nil {
-		return err
+	// Keep using the current refresh token if the server didn't rotate it.
+	if token.RefreshToken == "" {
+		token.RefreshToken = sess.token.RefreshToken
+		token.RefreshExpiry = sess.token.RefreshExpiry
 	}
-
-	// Update tokens
-	s.SetRefreshToken(ar.Token.Refresh)
-	s.client.header.Set("Authorization", fmt.Sprintf("Bearer %s", ar.Token.Session))
-	return nil
+	return sess.set(ctx, token)
 }
 
 // IsLoggedIn: Return true when client logged in and false otherwise.
 func (s *AuthService) IsLoggedIn() bool {
-	return s.client.header.Get("Authorization") != ""
+	token, _ := s.Token()
+	return token != nil && !token.refreshExpired()
+}
+
+// Token: Get a copy of the session's current token, nil if not logged in.
+func (s *AuthService) Token() (*Token, error) {
+	sess := s.client.session
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	if err := sess.load(context.Background()); err != nil {
+		return nil, err
+	}
+	if sess.token == nil {
+		return nil, nil
+	}
+	token := *sess.token
+	return &token, nil
+}
+
+// accessToken: Get a valid access token, refreshing it if needed. Empty if not logged in.
+//
+// If the session can't be refreshed anymore it is cleared, so only the current request fails.
+func (s *AuthService) accessToken(ctx context.Context) (string, error) {
+	sess := s.client.session
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	// Without a readable token the request is sent anonymously, the load error
+	// is only returned by Token and Refresh.
+	if err := sess.load(ctx); err != nil || sess.token == nil {
+		return "", nil
+	}
+	if sess.token.expired() {
+		if err := s.refresh(ctx); err != nil {
+			return "", fmt.Errorf("Failed to refresh access token: %w", err)
+		}
+	}
+	return sess.token.AccessToken, nil
 }
 
-// GetRefreshToken: Get the current refresh token of the client.
-func (s *AuthService) GetRefreshToken() string {
-	return s.client.refreshToken
+// requestToken: Request a new token from the token endpoint.
+func (s *AuthService) requestToken(ctx context.Context, form url.Values) (*Token, error) {
+	var res tokenResponse
+	if err := s.postForm(ctx, TokenPath, form, &res); err != nil {
+		return nil, err
+	}
+	if res.AccessToken == "" {
+		return nil, fmt.Errorf("Token response doesn't contain an access token")
+	}
+
+	now := time.Now()
+	token := &Token{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+		Expiry:       now.Add(time.Duration(res.ExpiresIn) * time.Second),
+	}
+	if res.RefreshExpiresIn > 0 {
+		token.RefreshExpiry = now.Add(time.Duration(res.RefreshExpiresIn) * time.Second)
+	}
+	return token, nil
 }
 
-// SetRefreshToken: Set the refresh token for the client.
-func (s *AuthService) SetRefreshToken(refreshToken string) {
-	s.client.refreshToken = refreshToken
+// postForm: Send a form to the auth server along with the client credentials,
+// decoding the response into res unless it is nil.
+func (s *AuthService) postForm(ctx context.Context, path string, form url.Values, res any) error {
+	if s.client.clientID == "" || s.client.clientSecret == "" {
+		return fmt.Errorf("ClientID and ClientSecret options are required for authentication")
+	}
+	form.Set("client_id", s.client.clientID)
+	form.Set("client_secret", s.client.clientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.client.baseAuth+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", s.client.Header().Get("User-Agent"))
+
+	resp, err := s.client.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		var er tokenResponse
+		json.NewDecoder(resp.Body).Decode(&er)
+		if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+			return fmt.Errorf("%w: (%d) %s: %s", ErrUnauthorized, resp.StatusCode, er.Error, er.ErrorDescription)
+		}
+		return fmt.Errorf("Non-200 status code from auth server -> (%d): %s: %s", resp.StatusCode, er.Error, er.ErrorDescription)
+	}
+	if res == nil {
+		return nil
+	}
+	if err = json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return fmt.Errorf("Failed to decode auth response: %s", err.Error())
+	}
+	return nil
 }
-*/
@@ -1,11 +1,176 @@
 package mangodex
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	AuthorPath     = "/author/%s"
+	AuthorListPath = "/author"
+)
+
+// AuthorService: Provides author services provided by the API.
+type AuthorService service
+
+// Author: Struct containing information on an author (or artist).
+type Author struct {
+	ID            string           `json:"id"`
+	Type          RelationshipType `json:"type"`
+	Attributes    AuthorAttributes `json:"attributes"`
+	Relationships []*Relationship  `json:"relationships"`
+}
+
+// GetName: Get the name of the author.
+func (a *Author) GetName() string {
+	return a.Attributes.Name
+}
+
+// GetBiography: Get biography of the author.
+//
+// If the requested language code biography is not found and fallback is true,
+// the first available value is returned, else an empty string.
+func (a *Author) GetBiography(langCode string, fallback bool) string {
+	return a.Attributes.Biography.GetLocalString(langCode, fallback)
+}
+
 // AuthorAttributes: Attributes for an author.
 type AuthorAttributes struct {
 	Name      string           `json:"name"`
 	ImageURL  string           `json:"imageUrl"`
 	Biography LocalisedStrings `json:"biography"`
+	Twitter   *string          `json:"twitter"`
+	Pixiv     *string          `json:"pixiv"`
+	MelonBook *string          `json:"melonBook"`
+	FanBox    *string          `json:"fanBox"`
+	Booth     *string          `json:"booth"`
+	Namicomi  *string          `json:"namicomi"`
+	NicoVideo *string          `json:"nicoVideo"`
+	Skeb      *string          `json:"skeb"`
+	Fantia    *string          `json:"fantia"`
+	Tumblr    *string          `json:"tumblr"`
+	Youtube   *string          `json:"youtube"`
+	Weibo     *string          `json:"weibo"`
+	Naver     *string          `json:"naver"`
+	Website   *string          `json:"website"`
 	Version   int              `json:"version"`
 	CreatedAt string           `json:"createdAt"`
 	UpdatedAt string           `json:"updatedAt"`
 }
+
+// AuthorListParams: Typed query params for AuthorService.List, encoded with Values.
+//
+// Zero values are left out of the query.
+type AuthorListParams struct {
+	Limit    int
+	Offset   int
+	IDs      []string
+	Name     string
+	Order    AuthorOrder
+	Includes []RelationshipType
+}
+
+// AuthorOrder: Sort order for author lists, unset fields are ignored.
+type AuthorOrder struct {
+	Name OrderEnum
+}
+
+func (o AuthorOrder) fields() map[string]OrderEnum {
+	return map[string]OrderEnum{
+		"name": o.Name,
+	}
+}
+
+// Validate: Check the params for invalid values.
+func (p AuthorListParams) Validate() error {
+	if err := checkPagination(p.Limit, p.Offset, 100); err != nil {
+		return err
+	}
+	return checkOrder(p.Order.fields())
+}
+
+// Values: Validate and encode the params into MangaDex's query syntax.
+func (p AuthorListParams) Values() (url.Values, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	v := url.Values{}
+	setInt(v, "limit", p.Limit)
+	setInt(v, "offset", p.Offset)
+	addAll(v, "ids[]", p.IDs)
+	setString(v, "name", p.Name)
+	setOrder(v, p.Order.fields())
+	addAll(v, "includes[]", p.Includes)
+	return v, nil
+}
+
+// Get: Get author by author id.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Author/operation/get-author-id
+func (s *AuthorService) Get(id string, params url.Values) (*Author, error) {
+	return s.GetContext(context.Background(), id, params)
+}
+
+// GetContext: Get with custom context.
+func (s *AuthorService) GetContext(ctx context.Context, id string, params url.Values) (author *Author, err error) {
+	u := s.client.apiURL(fmt.Sprintf(AuthorPath, id))
+	u.RawQuery = params.Encode()
+
+	var res DexResponse
+	err = s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(res.Data, &author)
+	if err != nil {
+		return nil, err
+	}
+
+	return author, nil
+}
+
+// List: Get author list.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Author/operation/get-author
+func (s *AuthorService) List(params url.Values) ([]*Author, error) {
+	return s.ListContext(context.Background(), params)
+}
+
+// ListContext: List with custom context.
+func (s *AuthorService) ListContext(ctx context.Context, params url.Values) ([]*Author, error) {
+	res, err := s.ListPageContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// ListPage: Get a page of the author list, along with the pagination metadata.
+func (s *AuthorService) ListPage(params url.Values) (*ListResult[*Author], error) {
+	return s.ListPageContext(context.Background(), params)
+}
+
+// ListPageContext: ListPage with custom context.
+func (s *AuthorService) ListPageContext(ctx context.Context, params url.Values) (*ListResult[*Author], error) {
+	u := s.client.apiURL(AuthorListPath)
+	u.RawQuery = params.Encode()
+
+	return requestList[*Author](ctx, s.client, u.String())
+}
+
+// ListPager: Get a pager over all the authors matching the params, see Pager.
+func (s *AuthorService) ListPager(params url.Values) *Pager[*Author] {
+	return s.ListPagerContext(context.Background(), params)
+}
+
+// ListPagerContext: ListPager with custom context.
+func (s *AuthorService) ListPagerContext(ctx context.Context, params url.Values) *Pager[*Author] {
+	u := s.client.apiURL(AuthorListPath)
+
+	return newPager[*Author](ctx, s.client, *u, params)
+}
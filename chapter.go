@@ -1,18 +1,23 @@
 package mangodex
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
+	"time"
 )
 
 const (
-	ChapterPath       = "/chapter/%s"
-	ChapterListPath   = "/chapter"
-	MangaChaptersPath = "/manga/%s/feed" // TODO: move to manga.go?
-	// MangaReadMarkersPath = "/manga/%s/read"
+	ChapterPath               = "/chapter/%s"
+	ChapterListPath           = "/chapter"
+	MangaChaptersPath         = "/manga/%s/feed" // TODO: move to manga.go?
+	MangaReadMarkersPath      = "/manga/%s/read"
+	MangaReadMarkersBatchPath = "/manga/read"
 )
 
 // ChapterService: Provides chapter services provided by the API.
@@ -54,16 +59,133 @@ type ChapterAttributes struct {
 	ReadableAt         string  `json:"readableAt"`
 }
 
+// ChapterFeedParams: Typed query params for ChapterService.List and ChapterService.GetMangaChapters, encoded with Values.
+//
+// Zero values are left out of the query. IDs, Title, Groups, Manga, Volume and Chapter
+// are only supported by ChapterService.List, which also only allows a Limit of up to 100.
+type ChapterFeedParams struct {
+	Limit                    int
+	Offset                   int
+	IDs                      []string
+	Title                    string
+	Groups                   []string
+	Uploaders                []string
+	Manga                    string
+	Volume                   []string
+	Chapter                  []string
+	TranslatedLanguage       []string
+	OriginalLanguage         []string
+	ExcludedOriginalLanguage []string
+	ContentRating            []ContentRating
+	ExcludedGroups           []string
+	ExcludedUploaders        []string
+	IncludeFutureUpdates     *bool
+	IncludeEmptyPages        *bool
+	IncludeFuturePublishAt   *bool
+	IncludeExternalURL       *bool
+	CreatedAtSince           time.Time
+	UpdatedAtSince           time.Time
+	PublishAtSince           time.Time
+	Order                    ChapterOrder
+	Includes                 []RelationshipType
+}
+
+// ChapterOrder: Sort order for chapter lists and feeds, unset fields are ignored.
+type ChapterOrder struct {
+	CreatedAt  OrderEnum
+	UpdatedAt  OrderEnum
+	PublishAt  OrderEnum
+	ReadableAt OrderEnum
+	Volume     OrderEnum
+	Chapter    OrderEnum
+}
+
+func (o ChapterOrder) fields() map[string]OrderEnum {
+	return map[string]OrderEnum{
+		"createdAt":  o.CreatedAt,
+		"updatedAt":  o.UpdatedAt,
+		"publishAt":  o.PublishAt,
+		"readableAt": o.ReadableAt,
+		"volume":     o.Volume,
+		"chapter":    o.Chapter,
+	}
+}
+
+// Validate: Check the params for invalid values and combinations.
+func (p ChapterFeedParams) Validate() error {
+	if err := checkPagination(p.Limit, p.Offset, 500); err != nil {
+		return err
+	}
+	for _, group := range p.Groups {
+		if slices.Contains(p.ExcludedGroups, group) {
+			return fmt.Errorf("group %q is both included and excluded", group)
+		}
+	}
+	for _, uploader := range p.Uploaders {
+		if slices.Contains(p.ExcludedUploaders, uploader) {
+			return fmt.Errorf("uploader %q is both included and excluded", uploader)
+		}
+	}
+	for _, lang := range p.OriginalLanguage {
+		if slices.Contains(p.ExcludedOriginalLanguage, lang) {
+			return fmt.Errorf("original language %q is both included and excluded", lang)
+		}
+	}
+	if err := checkEnum("content rating", p.ContentRating,
+		ContentRatingSafe, ContentRatingSuggestive, ContentRatingErotica, ContentRatingPorn); err != nil {
+		return err
+	}
+	return checkOrder(p.Order.fields())
+}
+
+// Values: Validate and encode the params into MangaDex's query syntax.
+func (p ChapterFeedParams) Values() (url.Values, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	v := url.Values{}
+	setInt(v, "limit", p.Limit)
+	setInt(v, "offset", p.Offset)
+	addAll(v, "ids[]", p.IDs)
+	setString(v, "title", p.Title)
+	addAll(v, "groups[]", p.Groups)
+	addAll(v, "uploader[]", p.Uploaders)
+	setString(v, "manga", p.Manga)
+	addAll(v, "volume[]", p.Volume)
+	addAll(v, "chapter[]", p.Chapter)
+	addAll(v, "translatedLanguage[]", p.TranslatedLanguage)
+	addAll(v, "originalLanguage[]", p.OriginalLanguage)
+	addAll(v, "excludedOriginalLanguage[]", p.ExcludedOriginalLanguage)
+	addAll(v, "contentRating[]", p.ContentRating)
+	addAll(v, "excludedGroups[]", p.ExcludedGroups)
+	addAll(v, "excludedUploaders[]", p.ExcludedUploaders)
+	setFlag(v, "includeFutureUpdates", p.IncludeFutureUpdates)
+	setFlag(v, "includeEmptyPages", p.IncludeEmptyPages)
+	setFlag(v, "includeFuturePublishAt", p.IncludeFuturePublishAt)
+	setFlag(v, "includeExternalUrl", p.IncludeExternalURL)
+	setTime(v, "createdAtSince", p.CreatedAtSince)
+	setTime(v, "updatedAtSince", p.UpdatedAtSince)
+	setTime(v, "publishAtSince", p.PublishAtSince)
+	setOrder(v, p.Order.fields())
+	addAll(v, "includes[]", p.Includes)
+	return v, nil
+}
+
 // Get: Get chapter by chapter id.
 //
 // https://api.mangadex.org/docs/redoc.html#tag/Chapter/operation/get-chapter-id
-func (s *ChapterService) Get(id string, params url.Values) (chapter *Chapter, err error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = fmt.Sprintf(ChapterPath, id)
+func (s *ChapterService) Get(id string, params url.Values) (*Chapter, error) {
+	return s.GetContext(context.Background(), id, params)
+}
+
+// GetContext: Get with custom context.
+func (s *ChapterService) GetContext(ctx context.Context, id string, params url.Values) (chapter *Chapter, err error) {
+	u := s.client.apiURL(fmt.Sprintf(ChapterPath, id))
 	u.RawQuery = params.Encode()
 
 	var res DexResponse
-	err = s.client.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil, &res)
+	err = s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -78,22 +200,43 @@ func (s *ChapterService) Get(id string, params url.Values) (chapter *Chapter, er
 // List: Get chapter list.
 //
 // https://api.mangadex.org/docs/redoc.html#tag/Chapter/operation/get-chapter
-func (s *ChapterService) List(params url.Values) (chapterList []*Chapter, err error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = ChapterListPath
-	u.RawQuery = params.Encode()
+func (s *ChapterService) List(params url.Values) ([]*Chapter, error) {
+	return s.ListContext(context.Background(), params)
+}
 
-	var res DexResponse
-	err = s.client.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil, &res)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(res.Data, &chapterList)
+// ListContext: List with custom context.
+func (s *ChapterService) ListContext(ctx context.Context, params url.Values) ([]*Chapter, error) {
+	res, err := s.ListPageContext(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	return chapterList, nil
+	return res.Data, nil
+}
+
+// ListPage: Get a page of the chapter list, along with the pagination metadata.
+func (s *ChapterService) ListPage(params url.Values) (*ListResult[*Chapter], error) {
+	return s.ListPageContext(context.Background(), params)
+}
+
+// ListPageContext: ListPage with custom context.
+func (s *ChapterService) ListPageContext(ctx context.Context, params url.Values) (*ListResult[*Chapter], error) {
+	u := s.client.apiURL(ChapterListPath)
+	u.RawQuery = params.Encode()
+
+	return requestList[*Chapter](ctx, s.client, u.String())
+}
+
+// ListPager: Get a pager over all the chapters matching the params, see Pager.
+func (s *ChapterService) ListPager(params url.Values) *Pager[*Chapter] {
+	return s.ListPagerContext(context.Background(), params)
+}
+
+// ListPagerContext: ListPager with custom context.
+func (s *ChapterService) ListPagerContext(ctx context.Context, params url.Values) *Pager[*Chapter] {
+	u := s.client.apiURL(ChapterListPath)
+
+	return newPager[*Chapter](ctx, s.client, *u, params)
 }
 
 // GetMangaChapters: Get a list of chapters for a manga by manga id.
@@ -101,76 +244,205 @@ func (s *ChapterService) List(params url.Values) (chapterList []*Chapter, err er
 // https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/get-manga-id-feed
 //
 // TODO: move this to manga.go?
-func (s *ChapterService) GetMangaChapters(id string, params url.Values) (chapterList []*Chapter, err error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = fmt.Sprintf(MangaChaptersPath, id)
-	u.RawQuery = params.Encode()
+func (s *ChapterService) GetMangaChapters(id string, params url.Values) ([]*Chapter, error) {
+	return s.GetMangaChaptersContext(context.Background(), id, params)
+}
 
-	var res DexResponse
-	err = s.client.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil, &res)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(res.Data, &chapterList)
+// GetMangaChaptersContext: GetMangaChapters with custom context.
+func (s *ChapterService) GetMangaChaptersContext(ctx context.Context, id string, params url.Values) ([]*Chapter, error) {
+	res, err := s.GetMangaChaptersPageContext(ctx, id, params)
 	if err != nil {
 		return nil, err
 	}
 
-	return chapterList, nil
+	return res.Data, nil
+}
+
+// GetMangaChaptersPage: Get a page of the chapters for a manga by manga id, along with the pagination metadata.
+func (s *ChapterService) GetMangaChaptersPage(id string, params url.Values) (*ListResult[*Chapter], error) {
+	return s.GetMangaChaptersPageContext(context.Background(), id, params)
+}
+
+// GetMangaChaptersPageContext: GetMangaChaptersPage with custom context.
+func (s *ChapterService) GetMangaChaptersPageContext(ctx context.Context, id string, params url.Values) (*ListResult[*Chapter], error) {
+	u := s.client.apiURL(fmt.Sprintf(MangaChaptersPath, id))
+	u.RawQuery = params.Encode()
+
+	return requestList[*Chapter](ctx, s.client, u.String())
+}
+
+// GetMangaChaptersPager: Get a pager over all the chapters of a manga by manga id, see Pager.
+func (s *ChapterService) GetMangaChaptersPager(id string, params url.Values) *Pager[*Chapter] {
+	return s.GetMangaChaptersPagerContext(context.Background(), id, params)
+}
+
+// GetMangaChaptersPagerContext: GetMangaChaptersPager with custom context.
+func (s *ChapterService) GetMangaChaptersPagerContext(ctx context.Context, id string, params url.Values) *Pager[*Chapter] {
+	u := s.client.apiURL(fmt.Sprintf(MangaChaptersPath, id))
+
+	return newPager[*Chapter](ctx, s.client, *u, params)
 }
 
-// TODO: update viable methods later. Most of this is either deprecated
-// or the API changed drastically (due to auth being different).
-// The code is heavily outdated.
-/*
+// ReadMarkersBatchSize: Maximum amount of manga ids per batched read markers request.
+const ReadMarkersBatchSize = 100
+
 // ChapterReadMarkers: A response for getting a list of read chapters.
 type ChapterReadMarkers struct {
 	Result string   `json:"result"`
 	Data   []string `json:"data"`
 }
 
-func (rmr *ChapterReadMarkers) GetResult() string {
-	return rmr.Result
+// groupedReadMarkers: A response for getting the read chapters of many manga, grouped by manga id.
+type groupedReadMarkers struct {
+	Result string             `json:"result"`
+	Data   readMarkersByManga `json:"data"`
+}
+
+// readMarkersByManga: Read chapter ids keyed by manga id.
+type readMarkersByManga map[string][]string
+
+func (r *readMarkersByManga) UnmarshalJSON(data []byte) error {
+	// An empty array when none of the manga have read chapters.
+	return unmarshalObject(data, (*map[string][]string)(r))
+}
+
+// ReadMarkersDelta: Chapters marked as read and unread by a sync.
+type ReadMarkersDelta struct {
+	Read   []string
+	Unread []string
 }
 
-// GetReadMangaChapters: Get list of Chapter IDs that are marked as read for a specified manga ID.
-// https://api.mangadex.org/docs.html#operation/get-manga-chapter-readmarkers
-func (s *ChapterService) GetReadMangaChapters(id string) (*ChapterReadMarkers, error) {
+// GetReadMangaChapters: Get list of chapter ids that are marked as read by the logged user for a manga by manga id.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/ReadMarker/operation/get-manga-chapter-readmarkers
+func (s *ChapterService) GetReadMangaChapters(id string) ([]string, error) {
 	return s.GetReadMangaChaptersContext(context.Background(), id)
 }
 
 // GetReadMangaChaptersContext: GetReadMangaChapters with custom context.
-func (s *ChapterService) GetReadMangaChaptersContext(ctx context.Context, id string) (*ChapterReadMarkers, error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = fmt.Sprintf(MangaReadMarkersPath, id)
+func (s *ChapterService) GetReadMangaChaptersContext(ctx context.Context, id string) ([]string, error) {
+	u := s.client.apiURL(fmt.Sprintf(MangaReadMarkersPath, id))
 
 	var rmr ChapterReadMarkers
 	err := s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &rmr)
-	return &rmr, err
+	if err != nil {
+		return nil, err
+	}
+
+	return rmr.Data, nil
+}
+
+// GetReadMangaChaptersBatch: Get the chapter ids marked as read by the logged user for many manga by manga ids,
+// keyed by manga id. Large amounts of ids are requested in batches.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/ReadMarker/operation/get-manga-chapter-readmarkers-2
+func (s *ChapterService) GetReadMangaChaptersBatch(ids ...string) (map[string][]string, error) {
+	return s.GetReadMangaChaptersBatchContext(context.Background(), ids...)
 }
 
-// SetReadUnreadMangaChapters: Set read/unread manga chapters.
-func (s *ChapterService) SetReadUnreadMangaChapters(id string, read, unRead []string) (*Response, error) {
-	return s.SetReadUnreadMangaChaptersContext(context.Background(), id, read, unRead)
+// GetReadMangaChaptersBatchContext: GetReadMangaChaptersBatch with custom context.
+func (s *ChapterService) GetReadMangaChaptersBatchContext(ctx context.Context, ids ...string) (map[string][]string, error) {
+	read := map[string][]string{}
+	for start := 0; start < len(ids); start += ReadMarkersBatchSize {
+		u := s.client.apiURL(MangaReadMarkersBatchPath)
+		u.RawQuery = url.Values{
+			"ids[]":   ids[start:min(start+ReadMarkersBatchSize, len(ids))],
+			"grouped": {"true"},
+		}.Encode()
+
+		var res groupedReadMarkers
+		err := s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
+		if err != nil {
+			return nil, err
+		}
+		for id, chapters := range res.Data {
+			read[id] = chapters
+		}
+	}
+	return read, nil
+}
+
+// SetReadUnreadMangaChapters: Mark chapters of a manga by manga id as read and unread for the logged user.
+//
+// If updateHistory is true, the chapters are also added to the user's reading history.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/ReadMarker/operation/post-manga-chapter-readmarkers
+func (s *ChapterService) SetReadUnreadMangaChapters(id string, read, unread []string, updateHistory bool) error {
+	return s.SetReadUnreadMangaChaptersContext(context.Background(), id, read, unread, updateHistory)
 }
 
 // SetReadUnreadMangaChaptersContext: SetReadUnreadMangaChapters with custom context.
-func (s *ChapterService) SetReadUnreadMangaChaptersContext(ctx context.Context, id string, read, unRead []string) (*Response, error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = fmt.Sprintf(MangaReadMarkersPath, id)
+func (s *ChapterService) SetReadUnreadMangaChaptersContext(ctx context.Context, id string, read, unread []string, updateHistory bool) error {
+	u := s.client.apiURL(fmt.Sprintf(MangaReadMarkersPath, id))
+	u.RawQuery = url.Values{"updateHistory": {strconv.FormatBool(updateHistory)}}.Encode()
 
 	// Set request body.
 	req := map[string][]string{
-		"chapterIdsRead":   read,
-		"chapterIdsUnread": unRead,
+		"chapterIdsRead":   nonNil(read),
+		"chapterIdsUnread": nonNil(unread),
 	}
 	rBytes, err := json.Marshal(&req)
+	if err != nil {
+		return err
+	}
+
+	var res DexResponse
+	return s.client.RequestAndDecode(ctx, http.MethodPost, u.String(), bytes.NewBuffer(rBytes), &res)
+}
+
+// SyncReadMangaChapters: Make the logged user's read chapters of a manga by manga id match read,
+// pushing only the chapters whose read status differs from the server.
+//
+// Chapters read on the server but missing from read are marked as unread.
+func (s *ChapterService) SyncReadMangaChapters(id string, read []string, updateHistory bool) (*ReadMarkersDelta, error) {
+	return s.SyncReadMangaChaptersContext(context.Background(), id, read, updateHistory)
+}
+
+// SyncReadMangaChaptersContext: SyncReadMangaChapters with custom context.
+func (s *ChapterService) SyncReadMangaChaptersContext(ctx context.Context, id string, read []string, updateHistory bool) (*ReadMarkersDelta, error) {
+	serverRead, err := s.GetReadMangaChaptersContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sets of both sides, as chapter feeds can be thousands long.
+	local := make(map[string]struct{}, len(read))
+	for _, chapter := range read {
+		local[chapter] = struct{}{}
+	}
+	remote := make(map[string]struct{}, len(serverRead))
+	for _, chapter := range serverRead {
+		remote[chapter] = struct{}{}
+	}
+
+	delta := &ReadMarkersDelta{}
+	for _, chapter := range read {
+		if _, ok := remote[chapter]; !ok {
+			delta.Read = append(delta.Read, chapter)
+			// Mark it as seen, so duplicates are only sent once.
+			remote[chapter] = struct{}{}
+		}
+	}
+	for _, chapter := range serverRead {
+		if _, ok := local[chapter]; !ok {
+			delta.Unread = append(delta.Unread, chapter)
+		}
+	}
+	if len(delta.Read) == 0 && len(delta.Unread) == 0 {
+		return delta, nil
+	}
+
+	err = s.SetReadUnreadMangaChaptersContext(ctx, id, delta.Read, delta.Unread, updateHistory)
 	if err != nil {
 		return nil, err
 	}
+	return delta, nil
+}
 
-	var r Response
-	err = s.client.RequestAndDecode(ctx, http.MethodPost, u.String(), bytes.NewBuffer(rBytes), &r)
-	return &r, err
+// nonNil: Return an empty slice instead of nil, so it is encoded as an empty JSON array.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
 }
-*/
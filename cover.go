@@ -2,8 +2,6 @@ package mangodex
 
 import (
 	"context"
-	"encoding/json"
-	"net/http"
 	"net/url"
 )
 
@@ -36,20 +34,41 @@ type CoverAttributes struct {
 // List: Get manga cover list.
 //
 // https://api.mangadex.org/docs/redoc.html#tag/Cover/operation/get-cover
-func (s *CoverService) List(params url.Values) (coverList []*Cover, err error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = CoverListPath
-	u.RawQuery = params.Encode()
+func (s *CoverService) List(params url.Values) ([]*Cover, error) {
+	return s.ListContext(context.Background(), params)
+}
 
-	var res DexResponse
-	err = s.client.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil, &res)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(res.Data, &coverList)
+// ListContext: List with custom context.
+func (s *CoverService) ListContext(ctx context.Context, params url.Values) ([]*Cover, error) {
+	res, err := s.ListPageContext(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	return coverList, nil
+	return res.Data, nil
+}
+
+// ListPage: Get a page of the manga cover list, along with the pagination metadata.
+func (s *CoverService) ListPage(params url.Values) (*ListResult[*Cover], error) {
+	return s.ListPageContext(context.Background(), params)
+}
+
+// ListPageContext: ListPage with custom context.
+func (s *CoverService) ListPageContext(ctx context.Context, params url.Values) (*ListResult[*Cover], error) {
+	u := s.client.apiURL(CoverListPath)
+	u.RawQuery = params.Encode()
+
+	return requestList[*Cover](ctx, s.client, u.String())
+}
+
+// ListPager: Get a pager over all the covers matching the params, see Pager.
+func (s *CoverService) ListPager(params url.Values) *Pager[*Cover] {
+	return s.ListPagerContext(context.Background(), params)
+}
+
+// ListPagerContext: ListPager with custom context.
+func (s *CoverService) ListPagerContext(ctx context.Context, params url.Values) *Pager[*Cover] {
+	u := s.client.apiURL(CoverListPath)
+
+	return newPager[*Cover](ctx, s.client, *u, params)
 }
@@ -0,0 +1,310 @@
+package mangodex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	CustomListPath            = "/list/%s"
+	CustomListCreatePath      = "/list"
+	CustomListFeedPath        = "/list/%s/feed"
+	CustomListMangaPath       = "/manga/%s/list/%s"
+	UserCustomListsPath       = "/user/%s/list"
+	LoggedUserCustomListsPath = "/user/list"
+)
+
+// CustomListService: Provides custom list (MDList) services provided by the API.
+//
+// Creating, modifying and getting private lists require being logged in.
+type CustomListService service
+
+// CustomList: Struct containing information on a custom list.
+type CustomList struct {
+	ID            string               `json:"id"`
+	Type          RelationshipType     `json:"type"`
+	Attributes    CustomListAttributes `json:"attributes"`
+	Relationships []*Relationship      `json:"relationships"`
+}
+
+// GetMangaIDs: Get the ids of the manga in the list.
+func (l *CustomList) GetMangaIDs() []string {
+	var ids []string
+	for _, rel := range l.Relationships {
+		if rel.Type == RelationshipTypeManga {
+			ids = append(ids, rel.ID.String())
+		}
+	}
+	return ids
+}
+
+// CustomListAttributes: Attributes for a custom list.
+type CustomListAttributes struct {
+	Name       string               `json:"name"`
+	Visibility CustomListVisibility `json:"visibility"`
+	Version    int                  `json:"version"`
+}
+
+// CustomListBody: Request body for creating and updating a custom list, unset fields are left out.
+type CustomListBody struct {
+	Name       string               `json:"name,omitempty"`
+	Visibility CustomListVisibility `json:"visibility,omitempty"`
+	// Manga: Ids of the manga in the list, left unchanged on updates if nil.
+	// Replaces the whole list on updates otherwise, pointing to an empty slice clears the list.
+	Manga *[]string `json:"manga,omitempty"`
+	// Version: Current version of the list, required for updates.
+	Version int `json:"version,omitempty"`
+}
+
+// Get: Get custom list by custom list id.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/CustomList/operation/get-list-id
+func (s *CustomListService) Get(id string) (*CustomList, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext: Get with custom context.
+func (s *CustomListService) GetContext(ctx context.Context, id string) (*CustomList, error) {
+	u := s.client.apiURL(fmt.Sprintf(CustomListPath, id))
+
+	return s.send(ctx, http.MethodGet, u.String(), nil)
+}
+
+// Create: Create a custom list.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/CustomList/operation/post-list
+func (s *CustomListService) Create(body CustomListBody) (*CustomList, error) {
+	return s.CreateContext(context.Background(), body)
+}
+
+// CreateContext: Create with custom context.
+func (s *CustomListService) CreateContext(ctx context.Context, body CustomListBody) (*CustomList, error) {
+	if body.Name == "" {
+		return nil, fmt.Errorf("custom list name is required")
+	}
+	u := s.client.apiURL(CustomListCreatePath)
+
+	return s.send(ctx, http.MethodPost, u.String(), body)
+}
+
+// Update: Update a custom list by custom list id, body.Version must be the list's current version.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/CustomList/operation/put-list-id
+func (s *CustomListService) Update(id string, body CustomListBody) (*CustomList, error) {
+	return s.UpdateContext(context.Background(), id, body)
+}
+
+// UpdateContext: Update with custom context.
+func (s *CustomListService) UpdateContext(ctx context.Context, id string, body CustomListBody) (*CustomList, error) {
+	if body.Version < 1 {
+		return nil, fmt.Errorf("custom list version is required for updates")
+	}
+	if body.Manga != nil {
+		// Send an empty array rather than null to clear the list.
+		manga := nonNil(*body.Manga)
+		body.Manga = &manga
+	}
+	u := s.client.apiURL(fmt.Sprintf(CustomListPath, id))
+
+	return s.send(ctx, http.MethodPut, u.String(), body)
+}
+
+// Delete: Delete a custom list by custom list id.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/CustomList/operation/delete-list-id
+func (s *CustomListService) Delete(id string) error {
+	return s.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext: Delete with custom context.
+func (s *CustomListService) DeleteContext(ctx context.Context, id string) error {
+	u := s.client.apiURL(fmt.Sprintf(CustomListPath, id))
+
+	var res DexResponse
+	return s.client.RequestAndDecode(ctx, http.MethodDelete, u.String(), nil, &res)
+}
+
+// AddManga: Add a manga to a custom list.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/post-manga-id-list-listId
+func (s *CustomListService) AddManga(listID, mangaID string) error {
+	return s.AddMangaContext(context.Background(), listID, mangaID)
+}
+
+// AddMangaContext: AddManga with custom context.
+func (s *CustomListService) AddMangaContext(ctx context.Context, listID, mangaID string) error {
+	u := s.client.apiURL(fmt.Sprintf(CustomListMangaPath, mangaID, listID))
+
+	var res DexResponse
+	return s.client.RequestAndDecode(ctx, http.MethodPost, u.String(), nil, &res)
+}
+
+// RemoveManga: Remove a manga from a custom list.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/delete-manga-id-list-listId
+func (s *CustomListService) RemoveManga(listID, mangaID string) error {
+	return s.RemoveMangaContext(context.Background(), listID, mangaID)
+}
+
+// RemoveMangaContext: RemoveManga with custom context.
+func (s *CustomListService) RemoveMangaContext(ctx context.Context, listID, mangaID string) error {
+	u := s.client.apiURL(fmt.Sprintf(CustomListMangaPath, mangaID, listID))
+
+	var res DexResponse
+	return s.client.RequestAndDecode(ctx, http.MethodDelete, u.String(), nil, &res)
+}
+
+// ListForUser: Get the public custom lists of a user by user id.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/CustomList/operation/get-user-id-list
+func (s *CustomListService) ListForUser(userID string, params url.Values) ([]*CustomList, error) {
+	return s.ListForUserContext(context.Background(), userID, params)
+}
+
+// ListForUserContext: ListForUser with custom context.
+func (s *CustomListService) ListForUserContext(ctx context.Context, userID string, params url.Values) ([]*CustomList, error) {
+	res, err := s.ListForUserPageContext(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// ListForUserPage: Get a page of the public custom lists of a user by user id, along with the pagination metadata.
+func (s *CustomListService) ListForUserPage(userID string, params url.Values) (*ListResult[*CustomList], error) {
+	return s.ListForUserPageContext(context.Background(), userID, params)
+}
+
+// ListForUserPageContext: ListForUserPage with custom context.
+func (s *CustomListService) ListForUserPageContext(ctx context.Context, userID string, params url.Values) (*ListResult[*CustomList], error) {
+	u := s.client.apiURL(fmt.Sprintf(UserCustomListsPath, userID))
+	u.RawQuery = params.Encode()
+
+	return requestList[*CustomList](ctx, s.client, u.String())
+}
+
+// ListForUserPager: Get a pager over all the public custom lists of a user by user id, see Pager.
+func (s *CustomListService) ListForUserPager(userID string, params url.Values) *Pager[*CustomList] {
+	return s.ListForUserPagerContext(context.Background(), userID, params)
+}
+
+// ListForUserPagerContext: ListForUserPager with custom context.
+func (s *CustomListService) ListForUserPagerContext(ctx context.Context, userID string, params url.Values) *Pager[*CustomList] {
+	u := s.client.apiURL(fmt.Sprintf(UserCustomListsPath, userID))
+
+	return newPager[*CustomList](ctx, s.client, *u, params)
+}
+
+// ListForLoggedUser: Get the custom lists of the logged user, including private ones.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/CustomList/operation/get-user-list
+func (s *CustomListService) ListForLoggedUser(params url.Values) ([]*CustomList, error) {
+	return s.ListForLoggedUserContext(context.Background(), params)
+}
+
+// ListForLoggedUserContext: ListForLoggedUser with custom context.
+func (s *CustomListService) ListForLoggedUserContext(ctx context.Context, params url.Values) ([]*CustomList, error) {
+	res, err := s.ListForLoggedUserPageContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// ListForLoggedUserPage: Get a page of the custom lists of the logged user, along with the pagination metadata.
+func (s *CustomListService) ListForLoggedUserPage(params url.Values) (*ListResult[*CustomList], error) {
+	return s.ListForLoggedUserPageContext(context.Background(), params)
+}
+
+// ListForLoggedUserPageContext: ListForLoggedUserPage with custom context.
+func (s *CustomListService) ListForLoggedUserPageContext(ctx context.Context, params url.Values) (*ListResult[*CustomList], error) {
+	u := s.client.apiURL(LoggedUserCustomListsPath)
+	u.RawQuery = params.Encode()
+
+	return requestList[*CustomList](ctx, s.client, u.String())
+}
+
+// ListForLoggedUserPager: Get a pager over all the custom lists of the logged user, see Pager.
+func (s *CustomListService) ListForLoggedUserPager(params url.Values) *Pager[*CustomList] {
+	return s.ListForLoggedUserPagerContext(context.Background(), params)
+}
+
+// ListForLoggedUserPagerContext: ListForLoggedUserPager with custom context.
+func (s *CustomListService) ListForLoggedUserPagerContext(ctx context.Context, params url.Values) *Pager[*CustomList] {
+	u := s.client.apiURL(LoggedUserCustomListsPath)
+
+	return newPager[*CustomList](ctx, s.client, *u, params)
+}
+
+// Feed: Get the chapter feed of a custom list by custom list id.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/CustomList/operation/get-cl-id-feed
+func (s *CustomListService) Feed(id string, params url.Values) ([]*Chapter, error) {
+	return s.FeedContext(context.Background(), id, params)
+}
+
+// FeedContext: Feed with custom context.
+func (s *CustomListService) FeedContext(ctx context.Context, id string, params url.Values) ([]*Chapter, error) {
+	res, err := s.FeedPageContext(ctx, id, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// FeedPage: Get a page of the chapter feed of a custom list by custom list id, along with the pagination metadata.
+func (s *CustomListService) FeedPage(id string, params url.Values) (*ListResult[*Chapter], error) {
+	return s.FeedPageContext(context.Background(), id, params)
+}
+
+// FeedPageContext: FeedPage with custom context.
+func (s *CustomListService) FeedPageContext(ctx context.Context, id string, params url.Values) (*ListResult[*Chapter], error) {
+	u := s.client.apiURL(fmt.Sprintf(CustomListFeedPath, id))
+	u.RawQuery = params.Encode()
+
+	return requestList[*Chapter](ctx, s.client, u.String())
+}
+
+// FeedPager: Get a pager over the whole chapter feed of a custom list by custom list id, see Pager.
+func (s *CustomListService) FeedPager(id string, params url.Values) *Pager[*Chapter] {
+	return s.FeedPagerContext(context.Background(), id, params)
+}
+
+// FeedPagerContext: FeedPager with custom context.
+func (s *CustomListService) FeedPagerContext(ctx context.Context, id string, params url.Values) *Pager[*Chapter] {
+	u := s.client.apiURL(fmt.Sprintf(CustomListFeedPath, id))
+
+	return newPager[*Chapter](ctx, s.client, *u, params)
+}
+
+// send: Send the (optional) body as JSON and decode the custom list from the response.
+func (s *CustomListService) send(ctx context.Context, method, u string, body any) (list *CustomList, err error) {
+	var r io.Reader
+	if body != nil {
+		rBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(rBytes)
+	}
+
+	var res DexResponse
+	err = s.client.RequestAndDecode(ctx, method, u, r, &res)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(res.Data, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,331 @@
+package mangodex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+)
+
+const (
+	// DefaultDownloadConcurrency: Amount of pages downloaded at the same time when not specified.
+	DefaultDownloadConcurrency = 4
+	// DefaultPageAttempts: Attempts per page when not specified.
+	DefaultPageAttempts = 3
+	// DefaultMaxServerRefreshes: MD@Home server re-acquisitions per download when not specified.
+	DefaultMaxServerRefreshes = 3
+	// DefaultFallbackAfter: Consecutive page failures before falling back to the uploads server when not specified.
+	DefaultFallbackAfter = 3
+)
+
+// ChapterDownloadOptions: Options for a ChapterDownloader.
+type ChapterDownloadOptions struct {
+	// Quality: Either QualityData (default) or QualityDataSaver.
+	Quality string
+	// Concurrency: Maximum amount of pages downloaded at the same time, DefaultDownloadConcurrency if not set.
+	Concurrency int
+	// Report: Whether to report the result of each page download to MangaDex@Home, as MangaDex requests.
+	Report bool
+	// ForcePort443: Request MD@Home servers that listen on port 443, for networks that block other ports.
+	ForcePort443 bool
+
+	// PageAttempts: Attempts per page before failing the download, DefaultPageAttempts if not set.
+	// Page requests don't follow the client's RetryPolicy, each attempt is a single request.
+	PageAttempts int
+	// MaxServerRefreshes: How many times the MD@Home server can be requested again per download,
+	// on page failures or once it is stale, DefaultMaxServerRefreshes if zero and none if negative.
+	// Keep it low, the server endpoint is heavily rate limited.
+	MaxServerRefreshes int
+	// FallbackAfter: Consecutive page failures after which pages are fetched from the uploads server
+	// (see Options.BaseUploads) instead of MD@Home, DefaultFallbackAfter if zero and never if negative.
+	// The download also falls back once the server can't be requested again.
+	FallbackAfter int
+
+	// Progress: Called after each page download finishes, successfully or not.
+	//
+	// Calls are serialized, so it doesn't need to be safe for concurrent use.
+	Progress func(DownloadProgress)
+}
+
+// ChapterPage: A page of a chapter being downloaded.
+type ChapterPage struct {
+	// Index: Position of the page in the chapter, starting at 0.
+	Index    int
+	Filename string
+}
+
+// DownloadProgress: Progress event of a chapter download.
+type DownloadProgress struct {
+	Page ChapterPage
+	// Bytes: Amount of bytes written for the page.
+	Bytes int64
+	// Err: Why the page failed to download, nil on success.
+	Err error
+	// Done: Amount of pages finished so far, including this one.
+	Done  int
+	Total int
+}
+
+// PageWriterFunc: Provide the writer each page of a chapter is streamed to.
+//
+// Pages are downloaded concurrently, so it is called for pages out of order, use
+// ChapterPage.Index to keep the pages in order. If the writer is also an io.Closer,
+// it is closed once the page is done.
+//
+// When a page is retried it is called again for the same page, and anything written
+// to the previous writer must be discarded.
+type PageWriterFunc func(page ChapterPage) (io.Writer, error)
+
+// ChapterDownloader: Downloads all the pages of chapters from MangaDex@Home with bounded concurrency,
+// streaming each page instead of holding the chapter in memory.
+//
+// Failed pages are retried, requesting a new MD@Home server when the current one fails or
+// goes stale, and falling back to the uploads server after repeated failures.
+type ChapterDownloader struct {
+	service *AtHomeService
+	options ChapterDownloadOptions
+}
+
+// NewChapterDownloader: New chapter downloader with the given options.
+func (s *AtHomeService) NewChapterDownloader(options ChapterDownloadOptions) *ChapterDownloader {
+	if options.Quality == "" {
+		options.Quality = QualityData
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = DefaultDownloadConcurrency
+	}
+	if options.PageAttempts <= 0 {
+		options.PageAttempts = DefaultPageAttempts
+	}
+	if options.MaxServerRefreshes == 0 {
+		options.MaxServerRefreshes = DefaultMaxServerRefreshes
+	}
+	if options.FallbackAfter == 0 {
+		options.FallbackAfter = DefaultFallbackAfter
+	}
+	return &ChapterDownloader{service: s, options: options}
+}
+
+// Download: Download all the pages of a chapter by id, streaming each to the writer provided by open.
+//
+// The first failing page stops the download and its error is returned.
+func (d *ChapterDownloader) Download(id string, open PageWriterFunc) error {
+	return d.DownloadContext(context.Background(), id, open)
+}
+
+// DownloadContext: Download with custom context.
+func (d *ChapterDownloader) DownloadContext(ctx context.Context, id string, open PageWriterFunc) error {
+	params := url.Values{}
+	if d.options.ForcePort443 {
+		params.Set("forcePort443", "true")
+	}
+	server, err := d.service.GetContext(ctx, id, params)
+	if err != nil {
+		return err
+	}
+	return d.DownloadServerContext(ctx, server, open)
+}
+
+// DownloadServer: Download all the pages of a chapter from an already acquired MangaDex@Home server.
+func (d *ChapterDownloader) DownloadServer(server *AtHomeServer, open PageWriterFunc) error {
+	return d.DownloadServerContext(context.Background(), server, open)
+}
+
+// DownloadServerContext: DownloadServer with custom context.
+func (d *ChapterDownloader) DownloadServerContext(ctx context.Context, server *AtHomeServer, open PageWriterFunc) error {
+	filenames := server.Chapter.Pages(d.options.Quality)
+	if filenames == nil && d.options.Quality != QualityData && d.options.Quality != QualityDataSaver {
+		return fmt.Errorf("Unknown page quality %q", d.options.Quality)
+	}
+
+	state := &serverState{
+		server:        server,
+		refreshesLeft: max(d.options.MaxServerRefreshes, 0),
+		fallbackAfter: d.options.FallbackAfter,
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	pages := make(chan ChapterPage)
+	var wg sync.WaitGroup
+	var mu sync.Mutex // Serializes progress events.
+	done, succeeded := 0, 0
+	for range min(d.options.Concurrency, len(filenames)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pages {
+				n, err := d.downloadPage(ctx, state, page, open)
+				if err != nil {
+					err = fmt.Errorf("Failed to download page %d (%s): %w", page.Index, page.Filename, err)
+					cancel(err)
+				}
+
+				mu.Lock()
+				done++
+				if err == nil {
+					succeeded++
+				}
+				if d.options.Progress != nil {
+					d.options.Progress(DownloadProgress{Page: page, Bytes: n, Err: err, Done: done, Total: len(filenames)})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for i, filename := range filenames {
+		select {
+		case pages <- ChapterPage{Index: i, Filename: filename}:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(pages)
+	wg.Wait()
+
+	if succeeded == len(filenames) {
+		return nil
+	}
+	// The cause is the first failure, or the cancellation of the parent context.
+	return context.Cause(ctx)
+}
+
+// downloadPage: Stream a single page to its writer, retrying on another server if needed.
+func (d *ChapterDownloader) downloadPage(ctx context.Context, state *serverState, page ChapterPage, open PageWriterFunc) (n int64, err error) {
+	for attempt := 1; ; attempt++ {
+		server, generation, err := state.current(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		var retryable bool
+		n, retryable, err = d.writePage(ctx, server, page, open)
+		if err == nil {
+			state.succeeded()
+			return n, nil
+		}
+		if !retryable || attempt >= d.options.PageAttempts || ctx.Err() != nil {
+			if attempt > 1 {
+				err = fmt.Errorf("Failed after %d attempts: %w", attempt, err)
+			}
+			return n, err
+		}
+		state.failed(ctx, generation)
+	}
+}
+
+// writePage: Stream a single page from the server to its writer.
+//
+// Only failures to fetch the page are retryable, not failures of the writer.
+func (d *ChapterDownloader) writePage(ctx context.Context, server *AtHomeServer, page ChapterPage, open PageWriterFunc) (n int64, retryable bool, err error) {
+	w, err := open(page)
+	if err != nil {
+		return 0, false, err
+	}
+	if c, ok := w.(io.Closer); ok {
+		defer func() {
+			if cerr := c.Close(); err == nil && cerr != nil {
+				err, retryable = cerr, false
+			}
+		}()
+	}
+	tw := &trackingWriter{w: w}
+	// A single attempt per server, the retries are up to downloadPage so that failing servers are replaced.
+	n, err = server.writeChapterPage(ctx, d.options.Quality, page.Filename, tw, d.options.Report, server.client.requestOnce)
+	return n, tw.err == nil, err
+}
+
+// trackingWriter: Keeps the error of the wrapped writer, to tell it apart from read errors.
+type trackingWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *trackingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if err != nil {
+		w.err = err
+	}
+	return n, err
+}
+
+// serverState: The server a download fetches pages from, shared by its workers.
+//
+// Each change of server bumps the generation, so that concurrent failures on the
+// same server only trigger a single refresh.
+type serverState struct {
+	mu            sync.Mutex
+	server        *AtHomeServer
+	generation    int
+	refreshesLeft int
+	fallbackAfter int
+	failures      int // Consecutive page failures.
+}
+
+// current: Get the server to use and its generation, refreshing it first if it is stale.
+func (s *serverState) current(ctx context.Context) (*AtHomeServer, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.server.fallback && s.server.Stale() {
+		if err := s.refresh(ctx); err != nil && ctx.Err() != nil {
+			return nil, 0, err
+		}
+	}
+	return s.server, s.generation, nil
+}
+
+// succeeded: Record a successful page download.
+func (s *serverState) succeeded() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = 0
+}
+
+// failed: Record a page download failure on the server of the given generation,
+// moving to another server unless that already happened.
+func (s *serverState) failed(ctx context.Context, generation int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures++
+	if generation != s.generation || s.server.fallback {
+		return
+	}
+	if s.fallbackAfter > 0 && s.failures >= s.fallbackAfter {
+		s.useFallback()
+		return
+	}
+	s.refresh(ctx)
+}
+
+// refresh: Request a new MD@Home server for the chapter, falling back to the uploads server
+// when it can't be requested again or the request fails. s.mu must be held.
+func (s *serverState) refresh(ctx context.Context) error {
+	if s.refreshesLeft == 0 || s.server.chapterID == "" {
+		s.useFallback()
+		return nil
+	}
+	s.refreshesLeft--
+
+	server, err := s.server.client.AtHome.GetContext(ctx, s.server.chapterID, s.server.params)
+	if err != nil {
+		s.useFallback()
+		return fmt.Errorf("Failed to refresh MD@Home server: %w", err)
+	}
+	s.server = server
+	s.generation++
+	return nil
+}
+
+// useFallback: Fetch the pages from the uploads server from now on, s.mu must be held.
+func (s *serverState) useFallback() {
+	if s.fallbackAfter < 0 {
+		return
+	}
+	s.server = s.server.uploadsFallback()
+	s.generation++
+}
@@ -1,10 +1,118 @@
 package mangodex
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Sentinel errors matched by APIError through errors.Is, depending on the status code.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrMaintenance  = errors.New("under maintenance")
+)
+
+// APIError: Error for a request that got a non-200 response.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	// Errors: Errors reported by MangaDex, empty if the response couldn't be decoded (HTML response?).
+	Errors []Error
+	// RetryAfter: Wait requested by the server via Retry-After or X-RateLimit-Retry-After headers.
+	RetryAfter time.Duration
+	// RateLimitLimit: Value of X-RateLimit-Limit, -1 if missing.
+	RateLimitLimit int
+	// RateLimitRemaining: Value of X-RateLimit-Remaining, -1 if missing.
+	RateLimitRemaining int
+
+	decodeErr error
+}
+
+// newAPIError: Build the error for a non-200 response to the request, consuming its body.
+//
+// The request is passed explicitly as transports and middleware don't necessarily set resp.Request.
+func newAPIError(method, url string, resp *http.Response) *APIError {
+	e := &APIError{
+		StatusCode:         resp.StatusCode,
+		Method:             method,
+		URL:                url,
+		RateLimitLimit:     headerInt(resp.Header, "X-RateLimit-Limit"),
+		RateLimitRemaining: headerInt(resp.Header, "X-RateLimit-Remaining"),
+	}
+	e.RetryAfter, _ = retryAfter(resp.Header)
+
+	// Sometimes the error page is just plain HTML, so it can't be decoded into ErrorResponse
+	var er ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&er); err != nil {
+		e.decodeErr = err
+	} else {
+		e.Errors = er.Errors
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	var msg string
+	known := true
+	switch e.StatusCode {
+	case http.StatusForbidden:
+		msg = "403 Forbidden: Probably temporarily IP banned"
+	case http.StatusTooManyRequests:
+		msg = fmt.Sprintf("429 Too Many Requests: Retry-After: %s", e.RetryAfter)
+	case http.StatusServiceUnavailable:
+		msg = "503 Service Unavailable: MangaDex is temporarily down for maintenance"
+	default:
+		msg = fmt.Sprintf("Non-200 status code -> (%d)", e.StatusCode)
+		known = false
+	}
+	msg = fmt.Sprintf("%s [%s %s]", msg, e.Method, e.URL)
+
+	switch {
+	case len(e.Errors) != 0:
+		er := ErrorResponse{Errors: e.Errors}
+		return fmt.Sprintf("%s: %s", msg, strings.TrimSuffix(er.GetErrors(), "\n"))
+	case e.decodeErr != nil && !known:
+		return fmt.Sprintf("%s: Failed to decode into ErrorResponse (HTML response?): %s", msg, e.decodeErr.Error())
+	default:
+		return msg
+	}
+}
+
+// Is: Match the sentinel error corresponding to the status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrMaintenance:
+		return e.StatusCode == http.StatusServiceUnavailable
+	default:
+		return false
+	}
+}
+
+// headerInt: Get the integer value of a header, or -1 if missing or invalid.
+func headerInt(header http.Header, key string) int {
+	v, err := strconv.Atoi(header.Get(key))
+	if err != nil {
+		return -1
+	}
+	return v
+}
+
 // ErrorResponse: Typical response for errored requests.
 type ErrorResponse struct {
 	Result string  `json:"result"`
@@ -0,0 +1,309 @@
+package mangodex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	FollowedMangaPath     = "/user/follows/manga"
+	FollowedMangaFeedPath = "/user/follows/manga/feed"
+	FollowedGroupsPath    = "/user/follows/group"
+	FollowedUsersPath     = "/user/follows/user"
+	FollowedListsPath     = "/user/follows/list"
+	IsFollowingPath       = "/user/follows/%s/%s"
+	FollowPath            = "/%s/%s/follow"
+)
+
+// followSegments: Path segment used by the follow endpoints for each followable entity type.
+var followSegments = map[RelationshipType]string{
+	RelationshipTypeManga:           "manga",
+	RelationshipTypeScanlationGroup: "group",
+	RelationshipTypeUser:            "user",
+	RelationshipTypeCustomList:      "list",
+}
+
+// FollowService: Provides follows services provided by the API, for the logged user.
+type FollowService service
+
+// Manga: Get the manga followed by the logged user.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Follows/operation/get-user-follows-manga
+func (s *FollowService) Manga(params url.Values) ([]*Manga, error) {
+	return s.MangaContext(context.Background(), params)
+}
+
+// MangaContext: Manga with custom context.
+func (s *FollowService) MangaContext(ctx context.Context, params url.Values) ([]*Manga, error) {
+	res, err := s.MangaPageContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// MangaPage: Get a page of the manga followed by the logged user, along with the pagination metadata.
+func (s *FollowService) MangaPage(params url.Values) (*ListResult[*Manga], error) {
+	return s.MangaPageContext(context.Background(), params)
+}
+
+// MangaPageContext: MangaPage with custom context.
+func (s *FollowService) MangaPageContext(ctx context.Context, params url.Values) (*ListResult[*Manga], error) {
+	u := s.client.apiURL(FollowedMangaPath)
+	u.RawQuery = params.Encode()
+
+	return requestList[*Manga](ctx, s.client, u.String())
+}
+
+// MangaPager: Get a pager over all the manga followed by the logged user, see Pager.
+func (s *FollowService) MangaPager(params url.Values) *Pager[*Manga] {
+	return s.MangaPagerContext(context.Background(), params)
+}
+
+// MangaPagerContext: MangaPager with custom context.
+func (s *FollowService) MangaPagerContext(ctx context.Context, params url.Values) *Pager[*Manga] {
+	u := s.client.apiURL(FollowedMangaPath)
+
+	return newPager[*Manga](ctx, s.client, *u, params)
+}
+
+// MangaFeed: Get the chapter feed of the manga followed by the logged user.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Feed/operation/get-user-follows-manga-feed
+func (s *FollowService) MangaFeed(params url.Values) ([]*Chapter, error) {
+	return s.MangaFeedContext(context.Background(), params)
+}
+
+// MangaFeedContext: MangaFeed with custom context.
+func (s *FollowService) MangaFeedContext(ctx context.Context, params url.Values) ([]*Chapter, error) {
+	res, err := s.MangaFeedPageContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// MangaFeedPage: Get a page of the chapter feed of the manga followed by the logged user, along with the pagination metadata.
+func (s *FollowService) MangaFeedPage(params url.Values) (*ListResult[*Chapter], error) {
+	return s.MangaFeedPageContext(context.Background(), params)
+}
+
+// MangaFeedPageContext: MangaFeedPage with custom context.
+func (s *FollowService) MangaFeedPageContext(ctx context.Context, params url.Values) (*ListResult[*Chapter], error) {
+	u := s.client.apiURL(FollowedMangaFeedPath)
+	u.RawQuery = params.Encode()
+
+	return requestList[*Chapter](ctx, s.client, u.String())
+}
+
+// MangaFeedPager: Get a pager over the whole chapter feed of the manga followed by the logged user, see Pager.
+func (s *FollowService) MangaFeedPager(params url.Values) *Pager[*Chapter] {
+	return s.MangaFeedPagerContext(context.Background(), params)
+}
+
+// MangaFeedPagerContext: MangaFeedPager with custom context.
+func (s *FollowService) MangaFeedPagerContext(ctx context.Context, params url.Values) *Pager[*Chapter] {
+	u := s.client.apiURL(FollowedMangaFeedPath)
+
+	return newPager[*Chapter](ctx, s.client, *u, params)
+}
+
+// Groups: Get the scanlation groups followed by the logged user.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Follows/operation/get-user-follows-group
+func (s *FollowService) Groups(params url.Values) ([]*ScanlationGroup, error) {
+	return s.GroupsContext(context.Background(), params)
+}
+
+// GroupsContext: Groups with custom context.
+func (s *FollowService) GroupsContext(ctx context.Context, params url.Values) ([]*ScanlationGroup, error) {
+	res, err := s.GroupsPageContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// GroupsPage: Get a page of the scanlation groups followed by the logged user, along with the pagination metadata.
+func (s *FollowService) GroupsPage(params url.Values) (*ListResult[*ScanlationGroup], error) {
+	return s.GroupsPageContext(context.Background(), params)
+}
+
+// GroupsPageContext: GroupsPage with custom context.
+func (s *FollowService) GroupsPageContext(ctx context.Context, params url.Values) (*ListResult[*ScanlationGroup], error) {
+	u := s.client.apiURL(FollowedGroupsPath)
+	u.RawQuery = params.Encode()
+
+	return requestList[*ScanlationGroup](ctx, s.client, u.String())
+}
+
+// GroupsPager: Get a pager over all the scanlation groups followed by the logged user, see Pager.
+func (s *FollowService) GroupsPager(params url.Values) *Pager[*ScanlationGroup] {
+	return s.GroupsPagerContext(context.Background(), params)
+}
+
+// GroupsPagerContext: GroupsPager with custom context.
+func (s *FollowService) GroupsPagerContext(ctx context.Context, params url.Values) *Pager[*ScanlationGroup] {
+	u := s.client.apiURL(FollowedGroupsPath)
+
+	return newPager[*ScanlationGroup](ctx, s.client, *u, params)
+}
+
+// Users: Get the users followed by the logged user.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Follows/operation/get-user-follows-user
+func (s *FollowService) Users(params url.Values) ([]*User, error) {
+	return s.UsersContext(context.Background(), params)
+}
+
+// UsersContext: Users with custom context.
+func (s *FollowService) UsersContext(ctx context.Context, params url.Values) ([]*User, error) {
+	res, err := s.UsersPageContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// UsersPage: Get a page of the users followed by the logged user, along with the pagination metadata.
+func (s *FollowService) UsersPage(params url.Values) (*ListResult[*User], error) {
+	return s.UsersPageContext(context.Background(), params)
+}
+
+// UsersPageContext: UsersPage with custom context.
+func (s *FollowService) UsersPageContext(ctx context.Context, params url.Values) (*ListResult[*User], error) {
+	u := s.client.apiURL(FollowedUsersPath)
+	u.RawQuery = params.Encode()
+
+	return requestList[*User](ctx, s.client, u.String())
+}
+
+// UsersPager: Get a pager over all the users followed by the logged user, see Pager.
+func (s *FollowService) UsersPager(params url.Values) *Pager[*User] {
+	return s.UsersPagerContext(context.Background(), params)
+}
+
+// UsersPagerContext: UsersPager with custom context.
+func (s *FollowService) UsersPagerContext(ctx context.Context, params url.Values) *Pager[*User] {
+	u := s.client.apiURL(FollowedUsersPath)
+
+	return newPager[*User](ctx, s.client, *u, params)
+}
+
+// Lists: Get the custom lists followed by the logged user.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Follows/operation/get-user-follows-list
+func (s *FollowService) Lists(params url.Values) ([]*CustomList, error) {
+	return s.ListsContext(context.Background(), params)
+}
+
+// ListsContext: Lists with custom context.
+func (s *FollowService) ListsContext(ctx context.Context, params url.Values) ([]*CustomList, error) {
+	res, err := s.ListsPageContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// ListsPage: Get a page of the custom lists followed by the logged user, along with the pagination metadata.
+func (s *FollowService) ListsPage(params url.Values) (*ListResult[*CustomList], error) {
+	return s.ListsPageContext(context.Background(), params)
+}
+
+// ListsPageContext: ListsPage with custom context.
+func (s *FollowService) ListsPageContext(ctx context.Context, params url.Values) (*ListResult[*CustomList], error) {
+	u := s.client.apiURL(FollowedListsPath)
+	u.RawQuery = params.Encode()
+
+	return requestList[*CustomList](ctx, s.client, u.String())
+}
+
+// ListsPager: Get a pager over all the custom lists followed by the logged user, see Pager.
+func (s *FollowService) ListsPager(params url.Values) *Pager[*CustomList] {
+	return s.ListsPagerContext(context.Background(), params)
+}
+
+// ListsPagerContext: ListsPager with custom context.
+func (s *FollowService) ListsPagerContext(ctx context.Context, params url.Values) *Pager[*CustomList] {
+	u := s.client.apiURL(FollowedListsPath)
+
+	return newPager[*CustomList](ctx, s.client, *u, params)
+}
+
+// IsFollowing: Check if the logged user follows an entity by type and id.
+//
+// The type must be one of manga, scanlation group, user or custom list.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Follows/operation/get-user-follows-manga-id
+func (s *FollowService) IsFollowing(typ RelationshipType, id string) (bool, error) {
+	return s.IsFollowingContext(context.Background(), typ, id)
+}
+
+// IsFollowingContext: IsFollowing with custom context.
+func (s *FollowService) IsFollowingContext(ctx context.Context, typ RelationshipType, id string) (bool, error) {
+	segment, ok := followSegments[typ]
+	if !ok {
+		return false, fmt.Errorf("can't follow entities of type %q", typ)
+	}
+	u := s.client.apiURL(fmt.Sprintf(IsFollowingPath, segment, id))
+
+	// MangaDex responds with 404 when the entity isn't followed.
+	var res DexResponse
+	err := s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Follow: Follow an entity by type and id as the logged user.
+//
+// The type must be one of manga, scanlation group, user or custom list.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/post-manga-id-follow
+func (s *FollowService) Follow(typ RelationshipType, id string) error {
+	return s.FollowContext(context.Background(), typ, id)
+}
+
+// FollowContext: Follow with custom context.
+func (s *FollowService) FollowContext(ctx context.Context, typ RelationshipType, id string) error {
+	return s.setFollow(ctx, http.MethodPost, typ, id)
+}
+
+// Unfollow: Unfollow an entity by type and id as the logged user.
+//
+// The type must be one of manga, scanlation group, user or custom list.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/delete-manga-id-follow
+func (s *FollowService) Unfollow(typ RelationshipType, id string) error {
+	return s.UnfollowContext(context.Background(), typ, id)
+}
+
+// UnfollowContext: Unfollow with custom context.
+func (s *FollowService) UnfollowContext(ctx context.Context, typ RelationshipType, id string) error {
+	return s.setFollow(ctx, http.MethodDelete, typ, id)
+}
+
+// setFollow: Follow (POST) or unfollow (DELETE) an entity.
+func (s *FollowService) setFollow(ctx context.Context, method string, typ RelationshipType, id string) error {
+	segment, ok := followSegments[typ]
+	if !ok {
+		return fmt.Errorf("can't follow entities of type %q", typ)
+	}
+	u := s.client.apiURL(fmt.Sprintf(FollowPath, segment, id))
+
+	var res DexResponse
+	return s.client.RequestAndDecode(ctx, method, u.String(), nil, &res)
+}
@@ -0,0 +1,56 @@
+package mangodex
+
+import (
+	"context"
+	"net/http"
+)
+
+// headerKey: Context key for the per-request header overrides.
+type headerKey struct{}
+
+// WithHeader: Return a copy of ctx carrying header overrides for the requests made with it.
+//
+// The overrides replace the client's common headers (and the session's Authorization)
+// for the given keys, only for the requests made with the returned context:
+//
+//	ctx := mangodex.WithHeader(ctx, http.Header{"Accept-Language": {"ja"}})
+//	manga, err := client.Manga.GetContext(ctx, id, params)
+//
+// Overrides from a parent context are merged, with header taking precedence.
+func WithHeader(ctx context.Context, header http.Header) context.Context {
+	merged := headerFromContext(ctx).Clone()
+	if merged == nil {
+		merged = http.Header{}
+	}
+	for k, v := range header.Clone() {
+		merged[http.CanonicalHeaderKey(k)] = v
+	}
+	return context.WithValue(ctx, headerKey{}, merged)
+}
+
+// headerFromContext: Get the per-request header overrides, nil if there are none.
+func headerFromContext(ctx context.Context) http.Header {
+	header, _ := ctx.Value(headerKey{}).(http.Header)
+	return header
+}
+
+// Header: Get a copy of the common headers sent with every request.
+func (c *DexClient) Header() http.Header {
+	c.headerMu.RLock()
+	defer c.headerMu.RUnlock()
+	return c.header.Clone()
+}
+
+// SetHeader: Set a common header sent with every request, safe to call while requests are in flight.
+func (c *DexClient) SetHeader(key, value string) {
+	c.headerMu.Lock()
+	defer c.headerMu.Unlock()
+	c.header.Set(key, value)
+}
+
+// DelHeader: Delete a common header sent with every request, safe to call while requests are in flight.
+func (c *DexClient) DelHeader(key string) {
+	c.headerMu.Lock()
+	defer c.headerMu.Unlock()
+	c.header.Del(key)
+}
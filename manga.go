@@ -6,13 +6,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"time"
 )
 
 const (
-	MangaPath     = "/manga/%s"
-	MangaListPath = "/manga"
-	// CheckIfMangaFollowedPath = "/user/follows/manga/%s"
-	// ToggleMangaFollowPath    = "/manga/%s/follow"
+	MangaPath       = "/manga/%s"
+	MangaListPath   = "/manga"
+	MangaRandomPath = "/manga/random"
 )
 
 // MangaResponse: Manga response type, this differs from the common DexResponse type. Only used for some responses.
@@ -73,16 +74,199 @@ type MangaAttributes struct {
 	UpdatedAt              string             `json:"updatedAt"`
 }
 
+// MangaListParams: Typed query params for MangaService.List, encoded with Values.
+//
+// Zero values are left out of the query.
+type MangaListParams struct {
+	Limit                       int
+	Offset                      int
+	Title                       string
+	AuthorOrArtist              string
+	Authors                     []string
+	Artists                     []string
+	Year                        int
+	IncludedTags                []string
+	IncludedTagsMode            TagsMode
+	ExcludedTags                []string
+	ExcludedTagsMode            TagsMode
+	Status                      []PublicationStatus
+	OriginalLanguage            []string
+	ExcludedOriginalLanguage    []string
+	AvailableTranslatedLanguage []string
+	PublicationDemographic      []Demographic
+	IDs                         []string
+	ContentRating               []ContentRating
+	CreatedAtSince              time.Time
+	UpdatedAtSince              time.Time
+	Order                       MangaOrder
+	Includes                    []RelationshipType
+	HasAvailableChapters        *bool
+	Group                       string
+}
+
+// MangaOrder: Sort order for manga lists, unset fields are ignored.
+type MangaOrder struct {
+	Title                 OrderEnum
+	Year                  OrderEnum
+	CreatedAt             OrderEnum
+	UpdatedAt             OrderEnum
+	LatestUploadedChapter OrderEnum
+	FollowedCount         OrderEnum
+	Relevance             OrderEnum
+	Rating                OrderEnum
+}
+
+func (o MangaOrder) fields() map[string]OrderEnum {
+	return map[string]OrderEnum{
+		"title":                 o.Title,
+		"year":                  o.Year,
+		"createdAt":             o.CreatedAt,
+		"updatedAt":             o.UpdatedAt,
+		"latestUploadedChapter": o.LatestUploadedChapter,
+		"followedCount":         o.FollowedCount,
+		"relevance":             o.Relevance,
+		"rating":                o.Rating,
+	}
+}
+
+// Validate: Check the params for invalid values and combinations.
+func (p MangaListParams) Validate() error {
+	if err := checkPagination(p.Limit, p.Offset, 100); err != nil {
+		return err
+	}
+	if p.Year < 0 {
+		return fmt.Errorf("negative year %d", p.Year)
+	}
+	if err := checkEnum("included tags mode", []TagsMode{p.IncludedTagsMode}, "", TagsModeAnd, TagsModeOr); err != nil {
+		return err
+	}
+	if err := checkEnum("excluded tags mode", []TagsMode{p.ExcludedTagsMode}, "", TagsModeAnd, TagsModeOr); err != nil {
+		return err
+	}
+	for _, tag := range p.IncludedTags {
+		if slices.Contains(p.ExcludedTags, tag) {
+			return fmt.Errorf("tag %q is both included and excluded", tag)
+		}
+	}
+	if err := checkEnum("publication status", p.Status,
+		PublicationStatusOngoing, PublicationStatusCompleted, PublicationStatusHiatus, PublicationStatusCancelled); err != nil {
+		return err
+	}
+	if err := checkEnum("publication demographic", p.PublicationDemographic,
+		DemographicShounen, DemographicShoujo, DemographicJosei, DemographicSeinen, DemographicNone); err != nil {
+		return err
+	}
+	if err := checkEnum("content rating", p.ContentRating,
+		ContentRatingSafe, ContentRatingSuggestive, ContentRatingErotica, ContentRatingPorn); err != nil {
+		return err
+	}
+	for _, lang := range p.OriginalLanguage {
+		if slices.Contains(p.ExcludedOriginalLanguage, lang) {
+			return fmt.Errorf("original language %q is both included and excluded", lang)
+		}
+	}
+	if err := checkOrder(p.Order.fields()); err != nil {
+		return err
+	}
+	if p.Order.Relevance != "" && p.Title == "" {
+		return fmt.Errorf("relevance order requires a title search")
+	}
+	return nil
+}
+
+// Values: Validate and encode the params into MangaDex's query syntax.
+func (p MangaListParams) Values() (url.Values, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	v := url.Values{}
+	setInt(v, "limit", p.Limit)
+	setInt(v, "offset", p.Offset)
+	setString(v, "title", p.Title)
+	setString(v, "authorOrArtist", p.AuthorOrArtist)
+	addAll(v, "authors[]", p.Authors)
+	addAll(v, "artists[]", p.Artists)
+	setInt(v, "year", p.Year)
+	addAll(v, "includedTags[]", p.IncludedTags)
+	setString(v, "includedTagsMode", string(p.IncludedTagsMode))
+	addAll(v, "excludedTags[]", p.ExcludedTags)
+	setString(v, "excludedTagsMode", string(p.ExcludedTagsMode))
+	addAll(v, "status[]", p.Status)
+	addAll(v, "originalLanguage[]", p.OriginalLanguage)
+	addAll(v, "excludedOriginalLanguage[]", p.ExcludedOriginalLanguage)
+	addAll(v, "availableTranslatedLanguage[]", p.AvailableTranslatedLanguage)
+	addAll(v, "publicationDemographic[]", p.PublicationDemographic)
+	addAll(v, "ids[]", p.IDs)
+	addAll(v, "contentRating[]", p.ContentRating)
+	setTime(v, "createdAtSince", p.CreatedAtSince)
+	setTime(v, "updatedAtSince", p.UpdatedAtSince)
+	setOrder(v, p.Order.fields())
+	addAll(v, "includes[]", p.Includes)
+	setBool(v, "hasAvailableChapters", p.HasAvailableChapters)
+	setString(v, "group", p.Group)
+	return v, nil
+}
+
+// MangaRandomParams: Typed query params for MangaService.Random, encoded with Values.
+//
+// Zero values are left out of the query.
+type MangaRandomParams struct {
+	ContentRating    []ContentRating
+	IncludedTags     []string
+	IncludedTagsMode TagsMode
+	ExcludedTags     []string
+	ExcludedTagsMode TagsMode
+	Includes         []RelationshipType
+}
+
+// Validate: Check the params for invalid values and combinations.
+func (p MangaRandomParams) Validate() error {
+	// Same rules as the equivalent manga list params.
+	err := MangaListParams{
+		ContentRating:    p.ContentRating,
+		IncludedTags:     p.IncludedTags,
+		IncludedTagsMode: p.IncludedTagsMode,
+		ExcludedTags:     p.ExcludedTags,
+		ExcludedTagsMode: p.ExcludedTagsMode,
+	}.Validate()
+	if err != nil {
+		return err
+	}
+	return checkEnum("include", p.Includes,
+		RelationshipTypeManga, RelationshipTypeCoverArt, RelationshipTypeAuthor, RelationshipTypeArtist, RelationshipTypeTag)
+}
+
+// Values: Validate and encode the params into MangaDex's query syntax.
+func (p MangaRandomParams) Values() (url.Values, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	v := url.Values{}
+	addAll(v, "contentRating[]", p.ContentRating)
+	addAll(v, "includedTags[]", p.IncludedTags)
+	setString(v, "includedTagsMode", string(p.IncludedTagsMode))
+	addAll(v, "excludedTags[]", p.ExcludedTags)
+	setString(v, "excludedTagsMode", string(p.ExcludedTagsMode))
+	addAll(v, "includes[]", p.Includes)
+	return v, nil
+}
+
 // Get: Get a manga by manga id.
 //
 // https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/get-manga-id
-func (s *MangaService) Get(id string, params url.Values) (manga *Manga, err error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = fmt.Sprintf(MangaPath, id)
+func (s *MangaService) Get(id string, params url.Values) (*Manga, error) {
+	return s.GetContext(context.Background(), id, params)
+}
+
+// GetContext: Get with custom context.
+func (s *MangaService) GetContext(ctx context.Context, id string, params url.Values) (manga *Manga, err error) {
+	u := s.client.apiURL(fmt.Sprintf(MangaPath, id))
 	u.RawQuery = params.Encode()
 
 	var res MangaResponse
-	err = s.client.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil, &res)
+	err = s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -97,66 +281,66 @@ func (s *MangaService) Get(id string, params url.Values) (manga *Manga, err erro
 // List: Get manga list.
 //
 // https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/get-search-manga
-func (s *MangaService) List(params url.Values) (mangaList []*Manga, err error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = MangaListPath
-	u.RawQuery = params.Encode()
+func (s *MangaService) List(params url.Values) ([]*Manga, error) {
+	return s.ListContext(context.Background(), params)
+}
 
-	var res DexResponse
-	err = s.client.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil, &res)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(res.Data, &mangaList)
+// ListContext: List with custom context.
+func (s *MangaService) ListContext(ctx context.Context, params url.Values) ([]*Manga, error) {
+	res, err := s.ListPageContext(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	return mangaList, nil
+	return res.Data, nil
 }
 
-// TODO: update viable methods later. Most of this is either deprecated
-// or the API changed drastically (due to auth being different).
-// The code is heavily outdated.
-/*
-// CheckIfMangaFollowed : Check if a user follows a manga.
-func (s *MangaService) CheckIfMangaFollowed(id string) (bool, error) {
-	return s.CheckIfMangaFollowedContext(context.Background(), id)
+// ListPage: Get a page of the manga list, along with the pagination metadata.
+func (s *MangaService) ListPage(params url.Values) (*ListResult[*Manga], error) {
+	return s.ListPageContext(context.Background(), params)
 }
 
-// CheckIfMangaFollowedContext : CheckIfMangaFollowed with custom context.
-func (s *MangaService) CheckIfMangaFollowedContext(ctx context.Context, id string) (bool, error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = fmt.Sprintf(CheckIfMangaFollowedPath, id)
+// ListPageContext: ListPage with custom context.
+func (s *MangaService) ListPageContext(ctx context.Context, params url.Values) (*ListResult[*Manga], error) {
+	u := s.client.apiURL(MangaListPath)
+	u.RawQuery = params.Encode()
 
-	var r Response
-	err := s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &r)
-	if err != nil {
-		if strings.Contains(err.Error(), "404") {
-			return false, nil
-		}
-		return false, err
-	}
-	return true, nil
+	return requestList[*Manga](ctx, s.client, u.String())
+}
+
+// ListPager: Get a pager over all the manga matching the params, see Pager.
+func (s *MangaService) ListPager(params url.Values) *Pager[*Manga] {
+	return s.ListPagerContext(context.Background(), params)
+}
+
+// ListPagerContext: ListPager with custom context.
+func (s *MangaService) ListPagerContext(ctx context.Context, params url.Values) *Pager[*Manga] {
+	u := s.client.apiURL(MangaListPath)
+
+	return newPager[*Manga](ctx, s.client, *u, params)
 }
 
-// ToggleMangaFollowStatus :Toggle follow status for a manga.
-func (s *MangaService) ToggleMangaFollowStatus(id string, toFollow bool) (*Response, error) {
-	return s.ToggleMangaFollowStatusContext(context.Background(), id, toFollow)
+// Random: Get a random manga.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/get-manga-random
+func (s *MangaService) Random(params url.Values) (*Manga, error) {
+	return s.RandomContext(context.Background(), params)
 }
 
-// ToggleMangaFollowStatusContext  ToggleMangaFollowStatus with custom context.
-func (s *MangaService) ToggleMangaFollowStatusContext(ctx context.Context, id string, toFollow bool) (*Response, error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = fmt.Sprintf(ToggleMangaFollowPath, id)
+// RandomContext: Random with custom context.
+func (s *MangaService) RandomContext(ctx context.Context, params url.Values) (manga *Manga, err error) {
+	u := s.client.apiURL(MangaRandomPath)
+	u.RawQuery = params.Encode()
 
-	method := http.MethodPost // To follow
-	if !toFollow {
-		method = http.MethodDelete // To unfollow
+	var res MangaResponse
+	err = s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(res.Data, &manga)
+	if err != nil {
+		return nil, err
 	}
 
-	var r Response
-	err := s.client.RequestAndDecode(ctx, method, u.String(), nil, &r)
-	return &r, err
+	return manga, nil
 }
-*/
@@ -0,0 +1,24 @@
+package mangodex
+
+import "net/http"
+
+// Middleware: Wraps the transport of every request sent by the client (API, auth and MD@Home),
+// such as for logging, metrics, caching or fault injection.
+//
+// Each retry attempt goes through the middleware chain again.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc: Adapter to use ordinary functions as http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// chain: Wrap the transport with the middleware, the first one being the outermost.
+func chain(transport http.RoundTripper, middleware []Middleware) http.RoundTripper {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+	return transport
+}
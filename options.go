@@ -1,22 +1,120 @@
 package mangodex
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
 
 const defaultUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36"
 
 type Options struct {
 	UserAgent string
+
+	// BaseAPI: Base URL of the MangaDex API, such as the sandbox "https://api.mangadex.dev". Defaults to BaseAPI.
+	BaseAPI string
+	// BaseAuth: Base URL of the MangaDex auth server. Defaults to BaseAuth.
+	BaseAuth string
+	// MDHomeReportURL: URL where MD@Home page downloads are reported. Defaults to MDHomeReportURL.
+	MDHomeReportURL string
+	// BaseUploads: Base URL of the MangaDex uploads server, used as MD@Home fallback. Defaults to BaseUploads.
+	BaseUploads string
+
+	// HTTPClient: Client used to send the requests, a bare http.Client if nil. It is copied, not modified.
+	HTTPClient *http.Client
+	// Middleware: Chain wrapping the HTTPClient's transport (http.DefaultTransport if nil), first is outermost.
+	Middleware []Middleware
+
+	// RateLimit: Global client-side rate limit for API requests. Zero value disables it.
+	RateLimit RateLimit
+	// RouteRateLimits: Per-route client-side rate limits for API requests, applied on top of RateLimit.
+	//
+	// Keyed by path constant (such as GetMDHomeURLPath), where "%s" matches any path segment.
+	RouteRateLimits map[string]RateLimit
+
+	// Retry: Policy for retrying failed requests. Zero value disables retries.
+	Retry RetryPolicy
+
+	// ClientID: Personal API client id, required for authentication.
+	ClientID string
+	// ClientSecret: Personal API client secret, required for authentication.
+	ClientSecret string
+	// TokenStore: Where the session token is persisted, in memory if nil.
+	TokenStore TokenStore
 }
 
 func (o Options) validate() error {
 	if o.UserAgent == "" {
 		return fmt.Errorf("UserAgent is empty")
 	}
+	for i, m := range o.Middleware {
+		if m == nil {
+			return fmt.Errorf("Middleware[%d] is nil", i)
+		}
+	}
+	for name, u := range map[string]string{"BaseAPI": o.BaseAPI, "BaseAuth": o.BaseAuth, "MDHomeReportURL": o.MDHomeReportURL, "BaseUploads": o.BaseUploads} {
+		if err := validateURL(u); err != nil {
+			return fmt.Errorf("%s: %s", name, err.Error())
+		}
+	}
+	if err := o.RateLimit.validate(); err != nil {
+		return fmt.Errorf("RateLimit: %s", err.Error())
+	}
+	for route, limit := range o.RouteRateLimits {
+		if err := limit.validate(); err != nil {
+			return fmt.Errorf("RouteRateLimits[%q]: %s", route, err.Error())
+		}
+	}
+	if (o.ClientID == "") != (o.ClientSecret == "") {
+		return fmt.Errorf("ClientID and ClientSecret must be set together")
+	}
+	if err := o.Retry.validate(); err != nil {
+		return fmt.Errorf("Retry: %s", err.Error())
+	}
 	return nil
 }
 
+// validateURL: Validate that u is either empty or an absolute URL.
+func validateURL(u string) error {
+	if u == "" {
+		return nil
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("%q is not an absolute URL", u)
+	}
+	return nil
+}
+
+// withDefaults: Fill the unset options that have a default value.
+func (o Options) withDefaults() Options {
+	if o.BaseAPI == "" {
+		o.BaseAPI = BaseAPI
+	}
+	if o.BaseAuth == "" {
+		o.BaseAuth = BaseAuth
+	}
+	if o.MDHomeReportURL == "" {
+		o.MDHomeReportURL = MDHomeReportURL
+	}
+	if o.BaseUploads == "" {
+		o.BaseUploads = BaseUploads
+	}
+	return o
+}
+
 func DefaultOptions() Options {
 	return Options{
-		UserAgent: defaultUserAgent,
+		UserAgent:       defaultUserAgent,
+		BaseAPI:         BaseAPI,
+		BaseAuth:        BaseAuth,
+		MDHomeReportURL: MDHomeReportURL,
+		BaseUploads:     BaseUploads,
+		RateLimit:       DefaultRateLimit,
+		RouteRateLimits: DefaultRouteRateLimits(),
+		Retry:           DefaultRetryPolicy(),
 	}
 }
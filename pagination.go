@@ -0,0 +1,174 @@
+package mangodex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// MaxListResults: MangaDex doesn't allow offset+limit to go past this on list endpoints.
+	MaxListResults = 10000
+	// DefaultPageLimit: Page size used by Pager when the params don't specify a limit.
+	DefaultPageLimit = 100
+)
+
+// ListResult: A page of results of a list endpoint, along with the pagination metadata.
+type ListResult[T any] struct {
+	Data   []T
+	Limit  int
+	Offset int
+	Total  int
+}
+
+// HasMore: Whether there are more results after this page that can be requested.
+func (r *ListResult[T]) HasMore() bool {
+	next := r.Offset + len(r.Data)
+	return len(r.Data) != 0 && next < r.Total && next < MaxListResults
+}
+
+// requestList: Request a list endpoint and decode its data into a ListResult.
+func requestList[T any](ctx context.Context, c *DexClient, u string) (*ListResult[T], error) {
+	var res DexResponse
+	err := c.RequestAndDecode(ctx, http.MethodGet, u, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	var list []T
+	err = json.Unmarshal(res.Data, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListResult[T]{
+		Data:   list,
+		Limit:  res.Limit,
+		Offset: res.Offset,
+		Total:  res.Total,
+	}, nil
+}
+
+// Pager: Iterates over all the results of a list endpoint, fetching pages as needed.
+//
+// Iteration starts at the params offset (if any) and stops at the last result or
+// at MaxListResults, whichever comes first. Usage:
+//
+//	pager := client.Chapter.GetMangaChaptersPager(id, params)
+//	for pager.Next() {
+//		chapter := pager.Item()
+//	}
+//	if err := pager.Err(); err != nil {
+//		// Handle error
+//	}
+type Pager[T any] struct {
+	ctx    context.Context
+	client *DexClient
+	u      url.URL
+	params url.Values
+	limit  int
+	offset int
+	total  int
+
+	items   []T
+	current T
+	fetched bool
+	done    bool
+	err     error
+}
+
+// newPager: Create a pager for the list endpoint at the given (absolute) URL.
+func newPager[T any](ctx context.Context, client *DexClient, u url.URL, params url.Values) *Pager[T] {
+	p := &Pager[T]{
+		ctx:    ctx,
+		client: client,
+		u:      u,
+		params: url.Values{},
+		limit:  DefaultPageLimit,
+	}
+	// Copy the params so the caller can't modify them mid-iteration.
+	for k, v := range params {
+		p.params[k] = append([]string(nil), v...)
+	}
+	if limit, err := strconv.Atoi(p.params.Get("limit")); err == nil && limit > 0 {
+		p.limit = limit
+	}
+	if offset, err := strconv.Atoi(p.params.Get("offset")); err == nil && offset > 0 {
+		p.offset = offset
+	}
+	return p
+}
+
+// Next: Advance to the next result, fetching the next page if needed.
+//
+// Returns false when there are no more results or an error occurred, check Err.
+func (p *Pager[T]) Next() bool {
+	if len(p.items) == 0 && !p.done {
+		p.fetch()
+	}
+	if len(p.items) == 0 {
+		return false
+	}
+	p.current, p.items = p.items[0], p.items[1:]
+	return true
+}
+
+// fetch: Get the next page into items.
+func (p *Pager[T]) fetch() {
+	// Clamp the last page so it doesn't go past the results ceiling.
+	limit := min(p.limit, MaxListResults-p.offset)
+	if limit <= 0 {
+		p.done = true
+		return
+	}
+
+	p.params.Set("limit", strconv.Itoa(limit))
+	p.params.Set("offset", strconv.Itoa(p.offset))
+	u := p.u
+	u.RawQuery = p.params.Encode()
+
+	res, err := requestList[T](p.ctx, p.client, u.String())
+	if err != nil {
+		p.err = err
+		p.done = true
+		return
+	}
+
+	p.items = res.Data
+	p.total = res.Total
+	p.fetched = true
+	p.offset += len(res.Data)
+	if !res.HasMore() {
+		p.done = true
+	}
+}
+
+// Item: The current result, valid after Next returns true.
+func (p *Pager[T]) Item() T {
+	return p.current
+}
+
+// Err: The error that stopped the iteration, if any.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// Total: Total amount of results reported by MangaDex, fetching the first page if needed.
+//
+// Note that only up to MaxListResults can be iterated, even if Total is greater.
+func (p *Pager[T]) Total() (int, error) {
+	if !p.fetched && !p.done {
+		p.fetch()
+	}
+	return p.total, p.err
+}
+
+// All: Collect all the remaining results.
+func (p *Pager[T]) All() ([]T, error) {
+	var all []T
+	for p.Next() {
+		all = append(all, p.Item())
+	}
+	return all, p.Err()
+}
@@ -0,0 +1,101 @@
+package mangodex
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DateFormat: Format of the dates used in query params (YYYY-MM-DDTHH:MM:SS), always in UTC.
+const DateFormat = "2006-01-02T15:04:05"
+
+// setInt: Set the key to n, unless it is zero.
+func setInt(v url.Values, key string, n int) {
+	if n != 0 {
+		v.Set(key, strconv.Itoa(n))
+	}
+}
+
+// setString: Set the key to s, unless it is empty.
+func setString(v url.Values, key, s string) {
+	if s != "" {
+		v.Set(key, s)
+	}
+}
+
+// setBool: Set the key to b, unless it is nil.
+func setBool(v url.Values, key string, b *bool) {
+	if b != nil {
+		v.Set(key, strconv.FormatBool(*b))
+	}
+}
+
+// setFlag: Set the key to 1 or 0 depending on b, unless it is nil.
+func setFlag(v url.Values, key string, b *bool) {
+	if b != nil {
+		if *b {
+			v.Set(key, "1")
+		} else {
+			v.Set(key, "0")
+		}
+	}
+}
+
+// setTime: Set the key to t formatted with DateFormat, unless it is zero.
+func setTime(v url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		v.Set(key, t.UTC().Format(DateFormat))
+	}
+}
+
+// addAll: Add all the values to the (array) key.
+func addAll[T ~string](v url.Values, key string, values []T) {
+	for _, s := range values {
+		v.Add(key, string(s))
+	}
+}
+
+// setOrder: Set order[field] for each field that has an order.
+func setOrder(v url.Values, fields map[string]OrderEnum) {
+	for field, order := range fields {
+		if order != "" {
+			v.Set(fmt.Sprintf("order[%s]", field), string(order))
+		}
+	}
+}
+
+// checkPagination: Validate limit and offset against the given max limit and MaxListResults.
+func checkPagination(limit, offset, maxLimit int) error {
+	if limit < 0 || limit > maxLimit {
+		return fmt.Errorf("limit %d not in range [0, %d]", limit, maxLimit)
+	}
+	if offset < 0 {
+		return fmt.Errorf("negative offset %d", offset)
+	}
+	if offset+limit > MaxListResults {
+		return fmt.Errorf("offset+limit %d exceeds the maximum of %d results", offset+limit, MaxListResults)
+	}
+	return nil
+}
+
+// checkEnum: Validate that all values are among the known ones.
+func checkEnum[T ~string](name string, values []T, known ...T) error {
+	for _, v := range values {
+		if !slices.Contains(known, v) {
+			return fmt.Errorf("unknown %s %q", name, v)
+		}
+	}
+	return nil
+}
+
+// checkOrder: Validate that all the orders are either unset, ascending or descending.
+func checkOrder(fields map[string]OrderEnum) error {
+	for field, order := range fields {
+		if order != "" && order != OrderAscending && order != OrderDescending {
+			return fmt.Errorf("unknown order %q for %s", order, field)
+		}
+	}
+	return nil
+}
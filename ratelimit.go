@@ -0,0 +1,179 @@
+package mangodex
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit: Maximum amount of requests allowed per period of time.
+//
+// The zero value means no limit.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+func (r RateLimit) validate() error {
+	if r.Requests < 0 || r.Period < 0 {
+		return fmt.Errorf("negative rate limit %d/%s", r.Requests, r.Period)
+	}
+	if r.Requests > 0 && r.Period == 0 {
+		return fmt.Errorf("rate limit of %d requests has no period", r.Requests)
+	}
+	return nil
+}
+
+// enabled: Whether the rate limit actually limits anything.
+func (r RateLimit) enabled() bool {
+	return r.Requests > 0 && r.Period > 0
+}
+
+// DefaultRateLimit: Global rate limit published by MangaDex, applies to all API requests per IP.
+//
+// https://api.mangadex.org/docs/2-limitations/
+var DefaultRateLimit = RateLimit{Requests: 5, Period: time.Second}
+
+// DefaultRouteRateLimits: Endpoint specific rate limits published by MangaDex, keyed by path.
+//
+// https://api.mangadex.org/docs/2-limitations/
+func DefaultRouteRateLimits() map[string]RateLimit {
+	return map[string]RateLimit{
+		GetMDHomeURLPath: {Requests: 40, Period: time.Minute},
+	}
+}
+
+// rateLimiter: Token bucket rate limiter with a global bucket and optional per-route buckets.
+//
+// Safe for concurrent use.
+type rateLimiter struct {
+	global *bucket
+	routes []*routeBucket
+}
+
+// routeBucket: Bucket for the requests whose path matches the route.
+type routeBucket struct {
+	segments []string
+	bucket   *bucket
+}
+
+func newRateLimiter(global RateLimit, routes map[string]RateLimit) *rateLimiter {
+	l := &rateLimiter{}
+	if global.enabled() {
+		l.global = newBucket(global)
+	}
+	for route, limit := range routes {
+		if !limit.enabled() {
+			continue
+		}
+		l.routes = append(l.routes, &routeBucket{
+			segments: strings.Split(strings.Trim(route, "/"), "/"),
+			bucket:   newBucket(limit),
+		})
+	}
+	return l
+}
+
+// Wait: Block until a request to the path is allowed or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context, path string) error {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var reserved []*bucket
+	for _, r := range l.routes {
+		if r.match(segments) {
+			if err := r.bucket.wait(ctx); err != nil {
+				releaseAll(reserved)
+				return err
+			}
+			reserved = append(reserved, r.bucket)
+		}
+	}
+	if l.global != nil {
+		if err := l.global.wait(ctx); err != nil {
+			releaseAll(reserved)
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseAll: Give back the tokens taken from the buckets by a wait that failed later on.
+func releaseAll(buckets []*bucket) {
+	for _, b := range buckets {
+		b.release()
+	}
+}
+
+// match: Whether the path segments match the route, where "%s" matches any one segment.
+func (r *routeBucket) match(segments []string) bool {
+	if len(segments) != len(r.segments) {
+		return false
+	}
+	for i, s := range r.segments {
+		if s != "%s" && s != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// bucket: A token bucket that refills continuously.
+type bucket struct {
+	mu       sync.Mutex
+	capacity float64
+	rate     float64 // Tokens per second.
+	tokens   float64
+	last     time.Time
+}
+
+func newBucket(limit RateLimit) *bucket {
+	return &bucket{
+		capacity: float64(limit.Requests),
+		rate:     float64(limit.Requests) / limit.Period.Seconds(),
+		tokens:   float64(limit.Requests),
+		last:     time.Now(),
+	}
+}
+
+// reserve: Take a token and return how long to wait before it can be used.
+func (b *bucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// release: Give back a reserved token that ended up not being used.
+func (b *bucket) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.capacity, b.tokens+1)
+}
+
+// wait: Take a token, blocking until it is available or ctx is done.
+func (b *bucket) wait(ctx context.Context) error {
+	d := b.reserve()
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		b.release()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,171 @@
+package mangodex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+const (
+	RatingListPath = "/rating"
+	RatingPath     = "/rating/%s"
+
+	// MinRating: Lowest score a manga can be rated with.
+	MinRating = 1
+	// MaxRating: Highest score a manga can be rated with.
+	MaxRating = 10
+	// RatingBatchSize: Maximum amount of manga ids per batched ratings request, to stay within URL length limits.
+	RatingBatchSize = 100
+)
+
+// RatingService: Provides manga rating services provided by the API, for the logged user.
+type RatingService service
+
+// Rating: The logged user's rating of a manga.
+type Rating struct {
+	Value     int       `json:"rating"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ratingsResponse: Ratings response type, keyed by manga id.
+type ratingsResponse struct {
+	Result  string         `json:"result"`
+	Ratings ratingsByManga `json:"ratings"`
+}
+
+// ratingsByManga: Ratings keyed by manga id.
+type ratingsByManga map[string]*Rating
+
+func (r *ratingsByManga) UnmarshalJSON(data []byte) error {
+	// An empty array when none of the manga are rated.
+	return unmarshalObject(data, (*map[string]*Rating)(r))
+}
+
+// checkRating: Validate that the score is within the allowed range.
+func checkRating(score int) error {
+	if score < MinRating || score > MaxRating {
+		return fmt.Errorf("rating %d not in range [%d, %d]", score, MinRating, MaxRating)
+	}
+	return nil
+}
+
+// Get: Get the logged user's ratings of the manga by manga ids, keyed by manga id.
+//
+// Manga not rated by the user are missing from the result. Large amounts of ids are requested in batches.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Rating/operation/get-rating
+func (s *RatingService) Get(ids ...string) (map[string]*Rating, error) {
+	return s.GetContext(context.Background(), ids...)
+}
+
+// GetContext: Get with custom context.
+func (s *RatingService) GetContext(ctx context.Context, ids ...string) (map[string]*Rating, error) {
+	ratings := map[string]*Rating{}
+	for start := 0; start < len(ids); start += RatingBatchSize {
+		u := s.client.apiURL(RatingListPath)
+		u.RawQuery = url.Values{"manga[]": ids[start:min(start+RatingBatchSize, len(ids))]}.Encode()
+
+		var res ratingsResponse
+		err := s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
+		if err != nil {
+			return nil, err
+		}
+		for id, rating := range res.Ratings {
+			ratings[id] = rating
+		}
+	}
+	return ratings, nil
+}
+
+// Set: Rate a manga by id for the logged user, replacing any previous rating.
+//
+// The score must be between MinRating and MaxRating.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Rating/operation/post-rating-manga-id
+func (s *RatingService) Set(id string, score int) error {
+	return s.SetContext(context.Background(), id, score)
+}
+
+// SetContext: Set with custom context.
+func (s *RatingService) SetContext(ctx context.Context, id string, score int) error {
+	if err := checkRating(score); err != nil {
+		return err
+	}
+	u := s.client.apiURL(fmt.Sprintf(RatingPath, id))
+
+	// Set request body.
+	req := map[string]int{"rating": score}
+	rBytes, err := json.Marshal(&req)
+	if err != nil {
+		return err
+	}
+
+	var res DexResponse
+	return s.client.RequestAndDecode(ctx, http.MethodPost, u.String(), bytes.NewBuffer(rBytes), &res)
+}
+
+// Delete: Remove the logged user's rating of a manga by id.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Rating/operation/delete-rating-manga-id
+func (s *RatingService) Delete(id string) error {
+	return s.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext: Delete with custom context.
+func (s *RatingService) DeleteContext(ctx context.Context, id string) error {
+	u := s.client.apiURL(fmt.Sprintf(RatingPath, id))
+
+	var res DexResponse
+	return s.client.RequestAndDecode(ctx, http.MethodDelete, u.String(), nil, &res)
+}
+
+// Import: Rate many manga at once from scores keyed by manga id, such as ratings exported from other trackers.
+//
+// All the scores are validated before anything is sent, and manga already rated
+// with the same score are skipped. Failing manga don't stop the import, their errors
+// are joined in the returned error. Returns the ids of the manga that were rated.
+func (s *RatingService) Import(scores map[string]int) ([]string, error) {
+	return s.ImportContext(context.Background(), scores)
+}
+
+// ImportContext: Import with custom context.
+func (s *RatingService) ImportContext(ctx context.Context, scores map[string]int) ([]string, error) {
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		if err := checkRating(scores[id]); err != nil {
+			return nil, fmt.Errorf("Invalid score for manga %q: %w", id, err)
+		}
+	}
+
+	current, err := s.GetContext(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	var rated []string
+	var errs []error
+	for _, id := range ids {
+		if r, ok := current[id]; ok && r.Value == scores[id] {
+			continue
+		}
+		if err = s.SetContext(ctx, id, scores[id]); err != nil {
+			if ctx.Err() != nil {
+				return rated, ctx.Err()
+			}
+			errs = append(errs, fmt.Errorf("Failed to rate manga %q: %w", id, err))
+			continue
+		}
+		rated = append(rated, id)
+	}
+	return rated, errors.Join(errs...)
+}
@@ -0,0 +1,126 @@
+package mangodex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	MangaReadingStatusesPath = "/manga/status"
+	MangaReadingStatusPath   = "/manga/%s/status"
+)
+
+// readingStatuses: All the known reading statuses.
+var readingStatuses = []ReadingStatus{
+	ReadingStatusReading,
+	ReadingStatusOnHold,
+	ReadingStatusPlanToRead,
+	ReadingStatusDropped,
+	ReadingStatusReReading,
+	ReadingStatusCompleted,
+}
+
+// readingStatusesResponse: A response for getting the reading statuses of the logged user's library.
+type readingStatusesResponse struct {
+	Result   string                 `json:"result"`
+	Statuses readingStatusesByManga `json:"statuses"`
+}
+
+// readingStatusesByManga: Reading statuses keyed by manga id.
+type readingStatusesByManga map[string]ReadingStatus
+
+func (r *readingStatusesByManga) UnmarshalJSON(data []byte) error {
+	// An empty array when the library is empty, or nothing matches the status.
+	return unmarshalObject(data, (*map[string]ReadingStatus)(r))
+}
+
+// readingStatusResponse: A response for getting the reading status of a single manga.
+type readingStatusResponse struct {
+	Result string        `json:"result"`
+	Status ReadingStatus `json:"status"`
+}
+
+// GetReadingStatuses: Get the reading status of every manga in the logged user's library, keyed by manga id.
+//
+// If status is not empty, only the manga with that reading status are returned.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/get-manga-status
+func (s *MangaService) GetReadingStatuses(status ReadingStatus) (map[string]ReadingStatus, error) {
+	return s.GetReadingStatusesContext(context.Background(), status)
+}
+
+// GetReadingStatusesContext: GetReadingStatuses with custom context.
+func (s *MangaService) GetReadingStatusesContext(ctx context.Context, status ReadingStatus) (map[string]ReadingStatus, error) {
+	if err := checkEnum("reading status", []ReadingStatus{status}, append(readingStatuses, "")...); err != nil {
+		return nil, err
+	}
+	u := s.client.apiURL(MangaReadingStatusesPath)
+	if status != "" {
+		u.RawQuery = url.Values{"status": {string(status)}}.Encode()
+	}
+
+	var res readingStatusesResponse
+	err := s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	if res.Statuses == nil {
+		res.Statuses = map[string]ReadingStatus{}
+	}
+
+	return map[string]ReadingStatus(res.Statuses), nil
+}
+
+// GetReadingStatus: Get the logged user's reading status of a manga by id, empty if it isn't in their library.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/get-manga-id-status
+func (s *MangaService) GetReadingStatus(id string) (ReadingStatus, error) {
+	return s.GetReadingStatusContext(context.Background(), id)
+}
+
+// GetReadingStatusContext: GetReadingStatus with custom context.
+func (s *MangaService) GetReadingStatusContext(ctx context.Context, id string) (ReadingStatus, error) {
+	u := s.client.apiURL(fmt.Sprintf(MangaReadingStatusPath, id))
+
+	var res readingStatusResponse
+	err := s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Status, nil
+}
+
+// SetReadingStatus: Set the logged user's reading status of a manga by id.
+//
+// An empty status removes the manga from the user's library.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/post-manga-id-status
+func (s *MangaService) SetReadingStatus(id string, status ReadingStatus) error {
+	return s.SetReadingStatusContext(context.Background(), id, status)
+}
+
+// SetReadingStatusContext: SetReadingStatus with custom context.
+func (s *MangaService) SetReadingStatusContext(ctx context.Context, id string, status ReadingStatus) error {
+	if err := checkEnum("reading status", []ReadingStatus{status}, append(readingStatuses, "")...); err != nil {
+		return err
+	}
+	u := s.client.apiURL(fmt.Sprintf(MangaReadingStatusPath, id))
+
+	// Set request body, a null status removes the manga from the library.
+	req := map[string]*ReadingStatus{"status": nil}
+	if status != "" {
+		req["status"] = &status
+	}
+	rBytes, err := json.Marshal(&req)
+	if err != nil {
+		return err
+	}
+
+	var res DexResponse
+	return s.client.RequestAndDecode(ctx, http.MethodPost, u.String(), bytes.NewBuffer(rBytes), &res)
+}
@@ -0,0 +1,148 @@
+package mangodex
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+const (
+	MangaRelationListPath = "/manga/%s/relation"
+)
+
+// FranchiseRelations: Relations followed by MangaService.Franchise by default.
+var FranchiseRelations = []MangaRelation{
+	MangaRelationPrequel,
+	MangaRelationSequel,
+	MangaRelationMainStory,
+	MangaRelationSideStory,
+}
+
+// Relation: Struct containing information on a relation from a manga to another.
+type Relation struct {
+	ID            string             `json:"id"`
+	Type          RelationshipType   `json:"type"`
+	Attributes    RelationAttributes `json:"attributes"`
+	Relationships []*Relationship    `json:"relationships"`
+}
+
+// RelationAttributes: Attributes for a relation.
+type RelationAttributes struct {
+	Relation MangaRelation `json:"relation"`
+	Version  int           `json:"version"`
+}
+
+// GetTarget: Get the related manga's relationship, nil if missing.
+//
+// Its Attributes are only set when requested with "includes[]=manga".
+func (r *Relation) GetTarget() *Relationship {
+	for _, rel := range r.Relationships {
+		if rel.Type == RelationshipTypeManga {
+			return rel
+		}
+	}
+	return nil
+}
+
+// Relations: Get the relations of a manga by manga id.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/get-manga-relation
+func (s *MangaService) Relations(id string, params url.Values) ([]*Relation, error) {
+	return s.RelationsContext(context.Background(), id, params)
+}
+
+// RelationsContext: Relations with custom context.
+func (s *MangaService) RelationsContext(ctx context.Context, id string, params url.Values) ([]*Relation, error) {
+	u := s.client.apiURL(fmt.Sprintf(MangaRelationListPath, id))
+	u.RawQuery = params.Encode()
+
+	res, err := requestList[*Relation](ctx, s.client, u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// FranchiseGraph: Graph of the manga reachable from a root manga through relations.
+type FranchiseGraph struct {
+	Root string
+	// Depth: Distance from the root of each manga in the graph, by manga id.
+	Depth map[string]int
+	// Edges: Relations from each visited manga, by manga id.
+	Edges map[string][]FranchiseEdge
+}
+
+// FranchiseEdge: A relation between two manga in a FranchiseGraph.
+type FranchiseEdge struct {
+	From     string
+	To       string
+	Relation MangaRelation
+}
+
+// Nodes: Get the ids of all the manga in the graph, in breadth-first order.
+func (g *FranchiseGraph) Nodes() []string {
+	nodes := make([]string, 0, len(g.Depth))
+	for id := range g.Depth {
+		nodes = append(nodes, id)
+	}
+	slices.SortFunc(nodes, func(a, b string) int {
+		return cmp.Or(cmp.Compare(g.Depth[a], g.Depth[b]), cmp.Compare(a, b))
+	})
+	return nodes
+}
+
+// Franchise: Build the franchise graph of a manga by walking its relations breadth-first.
+//
+// Only the given relations are followed (FranchiseRelations if none), up to maxDepth hops
+// from the root (unlimited if not positive). Each manga is visited once, so cycles are
+// not followed again. Every visited manga costs one request.
+func (s *MangaService) Franchise(id string, maxDepth int, relations ...MangaRelation) (*FranchiseGraph, error) {
+	return s.FranchiseContext(context.Background(), id, maxDepth, relations...)
+}
+
+// FranchiseContext: Franchise with custom context.
+func (s *MangaService) FranchiseContext(ctx context.Context, id string, maxDepth int, relations ...MangaRelation) (*FranchiseGraph, error) {
+	if len(relations) == 0 {
+		relations = FranchiseRelations
+	}
+
+	graph := &FranchiseGraph{
+		Root:  id,
+		Depth: map[string]int{id: 0},
+		Edges: map[string][]FranchiseEdge{},
+	}
+	queue := []string{id}
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+		depth := graph.Depth[current]
+		if maxDepth > 0 && depth >= maxDepth {
+			continue
+		}
+
+		related, err := s.RelationsContext(ctx, current, url.Values{})
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get relations of manga %q: %w", current, err)
+		}
+		for _, r := range related {
+			target := r.GetTarget()
+			if target == nil || !slices.Contains(relations, r.Attributes.Relation) {
+				continue
+			}
+			to := target.ID.String()
+			graph.Edges[current] = append(graph.Edges[current], FranchiseEdge{
+				From:     current,
+				To:       to,
+				Relation: r.Attributes.Relation,
+			})
+			if _, visited := graph.Depth[to]; !visited {
+				graph.Depth[to] = depth + 1
+				queue = append(queue, to)
+			}
+		}
+	}
+	return graph, nil
+}
@@ -41,6 +41,8 @@ func (a *Relationship) UnmarshalJSON(data []byte) error {
 		a.Attributes = &CoverAttributes{}
 	case RelationshipTypeUser:
 		a.Attributes = &UserAttributes{}
+	case RelationshipTypeCustomList:
+		a.Attributes = &CustomListAttributes{}
 	default:
 		a.Attributes = &json.RawMessage{}
 	}
@@ -0,0 +1,146 @@
+package mangodex
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy: Policy for retrying failed requests.
+//
+// Safe requests (GET, HEAD and OPTIONS) are retried on transport errors and on the retryable
+// status codes. PUT and DELETE requests are only retried on 429 and 503 responses, where the
+// server didn't process them, as replaying them is not safe on MangaDex (lists are version
+// checked, deleting twice fails). The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts: Maximum amount of attempts, including the first one. Values below 2 disable retries.
+	MaxAttempts int
+	// MinBackoff: Wait before the first retry, doubled on every subsequent retry.
+	MinBackoff time.Duration
+	// MaxBackoff: Upper bound for the computed backoff. Waits requested by the server
+	// (Retry-After and X-RateLimit-Retry-After headers) are honored even if longer.
+	MaxBackoff time.Duration
+	// Jitter: Fraction of the backoff, between 0 and 1, to randomly add or subtract.
+	Jitter float64
+	// RetryableStatus: HTTP status codes that are retried.
+	RetryableStatus []int
+}
+
+// DefaultRetryPolicy: Retry up to 3 times with exponential backoff on rate limits and server errors.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p RetryPolicy) validate() error {
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("negative MaxAttempts %d", p.MaxAttempts)
+	}
+	if p.MinBackoff < 0 || p.MaxBackoff < 0 {
+		return fmt.Errorf("negative backoff %s-%s", p.MinBackoff, p.MaxBackoff)
+	}
+	if p.MaxBackoff != 0 && p.MaxBackoff < p.MinBackoff {
+		return fmt.Errorf("MaxBackoff %s is lower than MinBackoff %s", p.MaxBackoff, p.MinBackoff)
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("Jitter %f not in range [0, 1]", p.Jitter)
+	}
+	return nil
+}
+
+// allows: Whether requests with the method may be retried at all.
+func (p RetryPolicy) allows(method string) bool {
+	if p.MaxAttempts < 2 {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryable: Whether the attempt with the method that resulted in resp (nil on errors) and err should be retried.
+//
+// Only transport errors are retried, errors that happen before sending the request
+// (such as auth failures or invalid URLs) won't go away by retrying.
+func (p RetryPolicy) retryable(method string, resp *http.Response, err error) bool {
+	safe := method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+	if resp == nil {
+		var te *transportError
+		return safe && errors.As(err, &te)
+	}
+	if !slices.Contains(p.RetryableStatus, resp.StatusCode) {
+		return false
+	}
+	return safe || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+}
+
+// transportError: Error from the HTTP client sending a request, as opposed to errors preparing it.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// delay: How long to wait after the given failed attempt (starting at 1), preferring the server's request.
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header); ok {
+			return d
+		}
+	}
+
+	d := p.MinBackoff << (attempt - 1)
+	if d < p.MinBackoff || (p.MaxBackoff != 0 && d > p.MaxBackoff) {
+		// Either capped or overflowed.
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d += time.Duration(float64(d) * p.Jitter * (2*rand.Float64() - 1))
+	}
+	return d
+}
+
+// retryAfter: Get the wait requested by the server, if any.
+//
+// Retry-After is either delay-seconds or an HTTP date, while
+// MangaDex's X-RateLimit-Retry-After is a unix timestamp.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	if v := header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return max(time.Duration(secs)*time.Second, 0), true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(time.Until(t), 0), true
+		}
+	}
+	if v := header.Get("X-RateLimit-Retry-After"); v != "" {
+		if ts, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return max(time.Until(time.Unix(ts, 0)), 0), true
+		}
+	}
+	return 0, false
+}
@@ -47,13 +47,17 @@ type ScanlationGroupAttributes struct {
 // Get: Get scanlation group by scanlation group id.
 //
 // https://api.mangadex.org/docs/redoc.html#tag/ScanlationGroup/operation/get-group-id
-func (s ScanlationGroupService) Get(id string, params url.Values) (group *ScanlationGroup, err error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = fmt.Sprintf(GroupGet, id)
+func (s ScanlationGroupService) Get(id string, params url.Values) (*ScanlationGroup, error) {
+	return s.GetContext(context.Background(), id, params)
+}
+
+// GetContext: Get with custom context.
+func (s ScanlationGroupService) GetContext(ctx context.Context, id string, params url.Values) (group *ScanlationGroup, err error) {
+	u := s.client.apiURL(fmt.Sprintf(GroupGet, id))
 	u.RawQuery = params.Encode()
 
 	var res DexResponse
-	err = s.client.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil, &res)
+	err = s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -68,20 +72,41 @@ func (s ScanlationGroupService) Get(id string, params url.Values) (group *Scanla
 // List: Get scanlation group list.
 //
 // https://api.mangadex.org/docs/redoc.html#tag/ScanlationGroup/operation/get-search-group
-func (s ScanlationGroupService) List(params url.Values) (groupList []*ScanlationGroup, err error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = fmt.Sprintf(GroupList)
-	u.RawQuery = params.Encode()
+func (s ScanlationGroupService) List(params url.Values) ([]*ScanlationGroup, error) {
+	return s.ListContext(context.Background(), params)
+}
 
-	var res DexResponse
-	err = s.client.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil, &res)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(res.Data, &groupList)
+// ListContext: List with custom context.
+func (s ScanlationGroupService) ListContext(ctx context.Context, params url.Values) ([]*ScanlationGroup, error) {
+	res, err := s.ListPageContext(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	return groupList, nil
+	return res.Data, nil
+}
+
+// ListPage: Get a page of the scanlation group list, along with the pagination metadata.
+func (s ScanlationGroupService) ListPage(params url.Values) (*ListResult[*ScanlationGroup], error) {
+	return s.ListPageContext(context.Background(), params)
+}
+
+// ListPageContext: ListPage with custom context.
+func (s ScanlationGroupService) ListPageContext(ctx context.Context, params url.Values) (*ListResult[*ScanlationGroup], error) {
+	u := s.client.apiURL(GroupList)
+	u.RawQuery = params.Encode()
+
+	return requestList[*ScanlationGroup](ctx, s.client, u.String())
+}
+
+// ListPager: Get a pager over all the scanlation groups matching the params, see Pager.
+func (s ScanlationGroupService) ListPager(params url.Values) *Pager[*ScanlationGroup] {
+	return s.ListPagerContext(context.Background(), params)
+}
+
+// ListPagerContext: ListPager with custom context.
+func (s ScanlationGroupService) ListPagerContext(ctx context.Context, params url.Values) *Pager[*ScanlationGroup] {
+	u := s.client.apiURL(GroupList)
+
+	return newPager[*ScanlationGroup](ctx, s.client, *u, params)
 }
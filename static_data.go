@@ -11,6 +11,7 @@ const (
 	DemographicShoujo  Demographic = "shoujo"
 	DemographicJosei   Demographic = "josei"
 	DemographicSeinen  Demographic = "seinen"
+	DemographicNone    Demographic = "none"
 )
 
 // Manga publication status
@@ -62,6 +63,7 @@ const (
 	RelationshipTypeTag             RelationshipType = "tag"
 	RelationshipTypeUser            RelationshipType = "user"
 	RelationshipTypeCustomList      RelationshipType = "custom_list"
+	RelationshipTypeMangaRelation   RelationshipType = "manga_relation"
 )
 
 type MangaRelation string
@@ -119,6 +121,24 @@ const (
 	TagGroupTheme   TagGroup = "theme"
 )
 
+// Custom list visibility
+
+type CustomListVisibility string
+
+const (
+	CustomListVisibilityPublic  CustomListVisibility = "public"
+	CustomListVisibilityPrivate CustomListVisibility = "private"
+)
+
+// Tag inclusion/exclusion modes
+
+type TagsMode string
+
+const (
+	TagsModeAnd TagsMode = "AND"
+	TagsModeOr  TagsMode = "OR"
+)
+
 type OrderEnum string
 
 const (
@@ -126,6 +146,9 @@ const (
 	OrderDescending = "desc"
 )
 
+// GetOrder: Sort order with JSON field names, never used by any request.
+//
+// Deprecated: Use MangaOrder with MangaListParams, or the sort order of the respective list params.
 type GetOrder struct {
 	Name          OrderEnum `json:"name,omitempty"`
 	CreatedAt     OrderEnum `json:"created_at,omitempty"`
@@ -0,0 +1,137 @@
+package mangodex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	MangaStatisticsPath       = "/statistics/manga/%s"
+	MangaStatisticsListPath   = "/statistics/manga"
+	ChapterStatisticsPath     = "/statistics/chapter/%s"
+	ChapterStatisticsListPath = "/statistics/chapter"
+	GroupStatisticsPath       = "/statistics/group/%s"
+	GroupStatisticsListPath   = "/statistics/group"
+
+	// StatisticsBatchSize: Maximum amount of ids per batched statistics request, to stay within URL length limits.
+	StatisticsBatchSize = 100
+)
+
+// statisticsResponse: Statistics response type, keyed by entity id.
+type statisticsResponse[T any] struct {
+	Result     string        `json:"result"`
+	Statistics map[string]*T `json:"statistics"`
+}
+
+// MangaStatistics: Statistics for a manga.
+type MangaStatistics struct {
+	Comments *CommentStatistics `json:"comments"`
+	Rating   RatingStatistics   `json:"rating"`
+	Follows  int                `json:"follows"`
+}
+
+// RatingStatistics: Rating statistics for a manga.
+type RatingStatistics struct {
+	// Average: Average rating, nil if there are no ratings.
+	Average  *float64 `json:"average"`
+	Bayesian float64  `json:"bayesian"`
+	// Distribution: Amount of ratings per score (1 to 10), only available when requesting a single manga.
+	Distribution map[int]int `json:"distribution"`
+}
+
+// ChapterStatistics: Statistics for a chapter.
+type ChapterStatistics struct {
+	Comments *CommentStatistics `json:"comments"`
+}
+
+// ScanlationGroupStatistics: Statistics for a scanlation group.
+type ScanlationGroupStatistics struct {
+	Comments *CommentStatistics `json:"comments"`
+}
+
+// CommentStatistics: Comment statistics for an entity. Nil when there is no comment thread.
+type CommentStatistics struct {
+	ThreadID     int `json:"threadId"`
+	RepliesCount int `json:"repliesCount"`
+}
+
+// requestStatistics: Get the statistics for the ids, keyed by id.
+//
+// A single id is requested through singlePath (which may contain more details),
+// more are requested through listPath in batches of StatisticsBatchSize using the key param.
+func requestStatistics[T any](ctx context.Context, c *DexClient, singlePath, listPath, key string, ids []string) (map[string]*T, error) {
+	statistics := map[string]*T{}
+	if len(ids) == 1 {
+		u := c.apiURL(fmt.Sprintf(singlePath, ids[0]))
+
+		var res statisticsResponse[T]
+		if err := c.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res); err != nil {
+			return nil, err
+		}
+		return res.Statistics, nil
+	}
+
+	for start := 0; start < len(ids); start += StatisticsBatchSize {
+		u := c.apiURL(listPath)
+		u.RawQuery = url.Values{key: ids[start:min(start+StatisticsBatchSize, len(ids))]}.Encode()
+
+		var res statisticsResponse[T]
+		if err := c.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res); err != nil {
+			return nil, err
+		}
+		for id, s := range res.Statistics {
+			statistics[id] = s
+		}
+	}
+	return statistics, nil
+}
+
+// Statistics: Get statistics for the manga by manga ids, keyed by manga id.
+//
+// Large amounts of ids are requested in batches. The rating distribution is only available for a single id.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Statistics/operation/get-statistics-manga-uuid
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Statistics/operation/get-statistics-manga
+func (s *MangaService) Statistics(ids ...string) (map[string]*MangaStatistics, error) {
+	return s.StatisticsContext(context.Background(), ids...)
+}
+
+// StatisticsContext: Statistics with custom context.
+func (s *MangaService) StatisticsContext(ctx context.Context, ids ...string) (map[string]*MangaStatistics, error) {
+	return requestStatistics[MangaStatistics](ctx, s.client, MangaStatisticsPath, MangaStatisticsListPath, "manga[]", ids)
+}
+
+// Statistics: Get statistics for the chapters by chapter ids, keyed by chapter id.
+//
+// Large amounts of ids are requested in batches.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Statistics/operation/get-statistics-chapter-uuid
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Statistics/operation/get-statistics-chapters
+func (s *ChapterService) Statistics(ids ...string) (map[string]*ChapterStatistics, error) {
+	return s.StatisticsContext(context.Background(), ids...)
+}
+
+// StatisticsContext: Statistics with custom context.
+func (s *ChapterService) StatisticsContext(ctx context.Context, ids ...string) (map[string]*ChapterStatistics, error) {
+	return requestStatistics[ChapterStatistics](ctx, s.client, ChapterStatisticsPath, ChapterStatisticsListPath, "chapter[]", ids)
+}
+
+// Statistics: Get statistics for the scanlation groups by group ids, keyed by group id.
+//
+// Large amounts of ids are requested in batches.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Statistics/operation/get-statistics-group-uuid
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Statistics/operation/get-statistics-groups
+func (s ScanlationGroupService) Statistics(ids ...string) (map[string]*ScanlationGroupStatistics, error) {
+	return s.StatisticsContext(context.Background(), ids...)
+}
+
+// StatisticsContext: Statistics with custom context.
+func (s ScanlationGroupService) StatisticsContext(ctx context.Context, ids ...string) (map[string]*ScanlationGroupStatistics, error) {
+	return requestStatistics[ScanlationGroupStatistics](ctx, s.client, GroupStatisticsPath, GroupStatisticsListPath, "group[]", ids)
+}
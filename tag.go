@@ -1,6 +1,25 @@
 package mangodex
 
-import "github.com/google/uuid"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TagListPath = "/manga/tag"
+)
+
+// ErrUnknownTag: Returned by TagResolver when a tag name doesn't match exactly one tag.
+var ErrUnknownTag = errors.New("unknown tag")
+
+// TagService: Provides tag services provided by the API.
+type TagService service
 
 // Tag: Struct containing information on a tag.
 type Tag struct {
@@ -25,3 +44,217 @@ type TagAttributes struct {
 func (t *Tag) GetName(langCode string, fallback bool) string {
 	return t.Attributes.Name.GetLocalString(langCode, fallback)
 }
+
+// List: Get the list of all manga tags.
+//
+// https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/get-manga-tag
+func (s *TagService) List() ([]*Tag, error) {
+	return s.ListContext(context.Background())
+}
+
+// ListContext: List with custom context.
+func (s *TagService) ListContext(ctx context.Context) ([]*Tag, error) {
+	u := s.client.apiURL(TagListPath)
+
+	res, err := requestList[*Tag](ctx, s.client, u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
+
+// Resolver: Get the client's TagResolver, which caches the tag list.
+func (s *TagService) Resolver() *TagResolver {
+	return s.client.tagResolver
+}
+
+// TagResolver: Resolves tag names (in any language, case-insensitive) to tags.
+//
+// The tag list is fetched on the first lookup and cached until Refresh. Safe for concurrent use.
+type TagResolver struct {
+	service *TagService
+
+	mu     sync.Mutex
+	tags   []*Tag
+	byName map[string][]*Tag // Lowercase localised names to the tags with that name.
+}
+
+// Refresh: Fetch the tag list again, replacing the cache.
+func (r *TagResolver) Refresh() error {
+	return r.RefreshContext(context.Background())
+}
+
+// RefreshContext: Refresh with custom context.
+func (r *TagResolver) RefreshContext(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.refresh(ctx)
+}
+
+// refresh: Fetch the tag list, mu must be held.
+func (r *TagResolver) refresh(ctx context.Context) error {
+	tags, err := r.service.ListContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	byName := map[string][]*Tag{}
+	for _, tag := range tags {
+		for _, name := range tag.Attributes.Name.Values {
+			key := strings.ToLower(name)
+			if !slices.Contains(byName[key], tag) {
+				byName[key] = append(byName[key], tag)
+			}
+		}
+	}
+	r.tags = tags
+	r.byName = byName
+	return nil
+}
+
+// Resolve: Get the tag with the given name, from any group.
+//
+// If there is no such tag, the error wraps ErrUnknownTag and lists the closest tag names.
+func (r *TagResolver) Resolve(name string) (*Tag, error) {
+	return r.ResolveInGroupContext(context.Background(), "", name)
+}
+
+// ResolveContext: Resolve with custom context.
+func (r *TagResolver) ResolveContext(ctx context.Context, name string) (*Tag, error) {
+	return r.ResolveInGroupContext(ctx, "", name)
+}
+
+// ResolveInGroup: Get the tag with the given name, only from group unless it is empty.
+//
+// If there is no such tag, the error wraps ErrUnknownTag and lists the closest tag names.
+func (r *TagResolver) ResolveInGroup(group TagGroup, name string) (*Tag, error) {
+	return r.ResolveInGroupContext(context.Background(), group, name)
+}
+
+// ResolveInGroupContext: ResolveInGroup with custom context.
+func (r *TagResolver) ResolveInGroupContext(ctx context.Context, group TagGroup, name string) (*Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.byName == nil {
+		if err := r.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var matches []*Tag
+	for _, tag := range r.byName[strings.ToLower(strings.TrimSpace(name))] {
+		if group == "" || tag.Attributes.Group == group {
+			matches = append(matches, tag)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		if suggestions := r.closest(group, name); len(suggestions) != 0 {
+			return nil, fmt.Errorf("%w %q, did you mean %s?", ErrUnknownTag, name, strings.Join(suggestions, ", "))
+		}
+		return nil, fmt.Errorf("%w %q", ErrUnknownTag, name)
+	default:
+		groups := make([]string, len(matches))
+		for i, tag := range matches {
+			groups[i] = string(tag.Attributes.Group)
+		}
+		return nil, fmt.Errorf("%w %q, ambiguous between groups %s", ErrUnknownTag, name, strings.Join(groups, ", "))
+	}
+}
+
+// ResolveIDs: Get the ids of the tags with the given names, ready to use in MangaListParams.
+func (r *TagResolver) ResolveIDs(names ...string) ([]string, error) {
+	return r.ResolveIDsInGroupContext(context.Background(), "", names...)
+}
+
+// ResolveIDsContext: ResolveIDs with custom context.
+func (r *TagResolver) ResolveIDsContext(ctx context.Context, names ...string) ([]string, error) {
+	return r.ResolveIDsInGroupContext(ctx, "", names...)
+}
+
+// ResolveIDsInGroup: Get the ids of the tags with the given names, only from group unless it is empty.
+func (r *TagResolver) ResolveIDsInGroup(group TagGroup, names ...string) ([]string, error) {
+	return r.ResolveIDsInGroupContext(context.Background(), group, names...)
+}
+
+// ResolveIDsInGroupContext: ResolveIDsInGroup with custom context.
+func (r *TagResolver) ResolveIDsInGroupContext(ctx context.Context, group TagGroup, names ...string) ([]string, error) {
+	ids := make([]string, len(names))
+	for i, name := range names {
+		tag, err := r.ResolveInGroupContext(ctx, group, name)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = tag.ID.String()
+	}
+	return ids, nil
+}
+
+// closest: Get the (quoted) tag names closest to name, mu must be held.
+func (r *TagResolver) closest(group TagGroup, name string) []string {
+	const maxSuggestions = 3
+	name = strings.ToLower(strings.TrimSpace(name))
+	maxDistance := max(2, len(name)/3)
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+	var candidates []candidate
+	for key, tags := range r.byName {
+		if group != "" && !slices.ContainsFunc(tags, func(t *Tag) bool { return t.Attributes.Group == group }) {
+			continue
+		}
+		distance := levenshtein(name, key)
+		if strings.Contains(key, name) || strings.Contains(name, key) {
+			distance = min(distance, 1)
+		}
+		if distance <= maxDistance {
+			candidates = append(candidates, candidate{tags[0].GetName("en", true), distance})
+		}
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		return strings.Compare(a.name, b.name)
+	})
+
+	var suggestions []string
+	for _, c := range candidates {
+		quoted := fmt.Sprintf("%q", c.name)
+		if !slices.Contains(suggestions, quoted) {
+			suggestions = append(suggestions, quoted)
+		}
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+	}
+	return suggestions
+}
+
+// levenshtein: Edit distance between two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
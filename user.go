@@ -5,13 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 )
 
 const (
-	GetUserPath = "/user/%s"
-	// GetUserFollowedMangaListPath = "/user/follows/manga"
-	// GetLoggedUserPath            = "/user/me"
+	GetUserPath       = "/user/%s"
+	GetLoggedUserPath = "/user/me"
 )
 
 // UserResponse: User response type, this differs from the common DexResponse type.
@@ -42,12 +40,16 @@ type UserAttributes struct {
 // Get: Get user by id.
 //
 // https://api.mangadex.org/docs/redoc.html#tag/User/operation/get-user-id
-func (s *UserService) Get(id string) (user *User, err error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = fmt.Sprintf(GetUserPath, id)
+func (s *UserService) Get(id string) (*User, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext: Get with custom context.
+func (s *UserService) GetContext(ctx context.Context, id string) (user *User, err error) {
+	u := s.client.apiURL(fmt.Sprintf(GetUserPath, id))
 
 	var res UserResponse
-	err = s.client.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil, &res)
+	err = s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -59,46 +61,26 @@ func (s *UserService) Get(id string) (user *User, err error) {
 	return user, err
 }
 
-// TODO: enable once Auth service is fixed.
-/*
-// GetUserFollowedMangaList: Get list of followed manga.
-//
-// https://api.mangadex.org/docs/redoc.html#tag/Follows/operation/get-user-follows-manga
-func (s *UserService) GetUserFollowedMangaList(params url.Values) ([]*Manga, error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = GetUserFollowedMangaListPath
-	u.RawQuery = params.Encode()
-
-	res, err := s.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	var mangaList []*Manga
-	err = json.Unmarshal(res.Data, &mangaList)
-	if err != nil {
-		return nil, err
-	}
-
-	return mangaList, err
-}
-
 // GetLoggedUser: Get logged user.
 //
 // https://api.mangadex.org/docs/redoc.html#tag/User/operation/get-user-me
 func (s *UserService) GetLoggedUser() (*User, error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = GetLoggedUserPath
+	return s.GetLoggedUserContext(context.Background())
+}
 
-	res, err := s.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil)
+// GetLoggedUserContext: GetLoggedUser with custom context.
+func (s *UserService) GetLoggedUserContext(ctx context.Context) (user *User, err error) {
+	u := s.client.apiURL(GetLoggedUserPath)
+
+	var res UserResponse
+	err = s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
 	if err != nil {
 		return nil, err
 	}
-	var user User
 	err = json.Unmarshal(res.Data, &user)
 	if err != nil {
 		return nil, err
 	}
 
-	return &user, err
+	return user, nil
 }
-*/
@@ -87,13 +87,17 @@ type VolumeChapter struct {
 // https://api.mangadex.org/docs/redoc.html#tag/Manga/operation/get-manga-aggregate
 //
 // TODO: integrate manga/id/aggregate to manga.go?
-func (s *VolumeService) List(id string, params url.Values) (volumeList map[string]*Volume, err error) {
-	u, _ := url.Parse(BaseAPI)
-	u.Path = fmt.Sprintf(MangaAggregatePath, id)
+func (s *VolumeService) List(id string, params url.Values) (map[string]*Volume, error) {
+	return s.ListContext(context.Background(), id, params)
+}
+
+// ListContext: List with custom context.
+func (s *VolumeService) ListContext(ctx context.Context, id string, params url.Values) (volumeList map[string]*Volume, err error) {
+	u := s.client.apiURL(fmt.Sprintf(MangaAggregatePath, id))
 	u.RawQuery = params.Encode()
 
 	var res VolumeResponse
-	err = s.client.RequestAndDecode(context.Background(), http.MethodGet, u.String(), nil, &res)
+	err = s.client.RequestAndDecode(ctx, http.MethodGet, u.String(), nil, &res)
 	if err != nil {
 		return nil, err
 	}